@@ -3,9 +3,11 @@ module youtube-analyzer
 go 1.21
 
 require (
+	cloud.google.com/go/firestore v1.18.0
 	firebase.google.com/go v1.0.2
 	github.com/go-echarts/go-echarts/v2 v2.4.1
 	github.com/joho/godotenv v1.5.1
+	golang.org/x/sync v0.10.0
 	google.golang.org/api v0.214.0
 )
 
@@ -15,7 +17,6 @@ require (
 	cloud.google.com/go/auth/oauth2adapt v0.2.6 // indirect
 	cloud.google.com/go/compute v1.29.0 // indirect
 	cloud.google.com/go/compute/metadata v0.6.0 // indirect
-	cloud.google.com/go/firestore v1.18.0 // indirect
 	cloud.google.com/go/longrunning v0.6.2 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
 	github.com/go-logr/logr v1.4.2 // indirect
@@ -35,7 +36,6 @@ require (
 	golang.org/x/crypto v0.31.0 // indirect
 	golang.org/x/net v0.33.0 // indirect
 	golang.org/x/oauth2 v0.24.0 // indirect
-	golang.org/x/sync v0.10.0 // indirect
 	golang.org/x/sys v0.28.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 	golang.org/x/time v0.8.0 // indirect
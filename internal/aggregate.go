@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// 여러 영상 집계시 보여줄 단골 댓글 작성자 수와 키워드 추이 단어 수
+const (
+	maxRecurringCommenters = 20
+	keywordTrendWords      = 10
+)
+
+// VideoSummary: 여러 영상 분석에서 영상 1개의 요약 (VideoID로 개별 분석 결과 조회)
+type VideoSummary struct {
+	VideoID     string          `json:"videoId"`
+	Title       string          `json:"title"`
+	Thumbnail   string          `json:"thumbnail"`
+	PublishedAt time.Time       `json:"publishedAt"`
+	PosCount    int             `json:"posCount"`
+	NegCount    int             `json:"negCount"`
+	NeuCount    int             `json:"neuCount"`
+	TotalCount  int             `json:"totalCount"`
+	Shares      []ShareEstimate `json:"shares"`
+	TopKeywords []string        `json:"topKeywords"`
+	AnalysisID  string          `json:"analysisId,omitempty"` // 저장된 개별 분석 기록 (상세 링크용)
+}
+
+// RecurringCommenter: 여러 영상에 댓글을 남긴 작성자와 감성 분포
+type RecurringCommenter struct {
+	Author       string `json:"author"`
+	VideoCount   int    `json:"videoCount"`
+	CommentCount int    `json:"commentCount"`
+	PosCount     int    `json:"posCount"`
+	NegCount     int    `json:"negCount"`
+	NeuCount     int    `json:"neuCount"`
+}
+
+// KeywordTrend: 전체 상위 키워드의 영상별 빈도 (Counts는 Videos와 같은 순서)
+type KeywordTrend struct {
+	Word   string `json:"word"`
+	Total  int    `json:"total"`
+	Counts []int  `json:"counts"`
+}
+
+// MultiVideoAnalysis: 여러 영상(채널, 재생목록)의 영상별/전체 감성, 단골 작성자, 키워드 추이
+type MultiVideoAnalysis struct {
+	Videos              []VideoSummary       `json:"videos"` // 업로드 시각 순서
+	PosCount            int                  `json:"posCount"`
+	NegCount            int                  `json:"negCount"`
+	NeuCount            int                  `json:"neuCount"`
+	TotalCount          int                  `json:"totalCount"`
+	Shares              []ShareEstimate      `json:"shares"`
+	WordFreq            map[string]int       `json:"wordFreq"`
+	TopKeywords         []string             `json:"topKeywords"`
+	RecurringCommenters []RecurringCommenter `json:"recurringCommenters"`
+	KeywordTrends       []KeywordTrend       `json:"keywordTrends"`
+	FailedVideoIDs      []string             `json:"failedVideoIds,omitempty"` // 댓글 사용 중지 등으로 분석하지 못한 영상
+}
+
+// AnalyzeVideos: 영상들을 동시 실행 한도 안에서 분석 (실패한 영상은 건너뛰고 ID만 반환)
+func AnalyzeVideos(ctx context.Context, videoIDs []string, opt AnalysisOptions) ([]*AnalysisResult, []string) {
+	results := make([]*AnalysisResult, len(videoIDs))
+	var wg sync.WaitGroup
+	for i, id := range videoIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			results[i], _ = CachedAnalysis(ctx, id, opt)
+		}(i, id)
+	}
+	wg.Wait()
+	ok := make([]*AnalysisResult, 0, len(results))
+	var failed []string
+	for i, res := range results {
+		if res == nil {
+			failed = append(failed, videoIDs[i])
+			continue
+		}
+		ok = append(ok, res)
+	}
+	return ok, failed
+}
+
+// AggregateAnalyses: 영상별 분석 결과를 업로드 시각 순으로 정렬해 전체 감성, 키워드, 단골 작성자, 키워드 추이 집계
+func AggregateAnalyses(results []*AnalysisResult) *MultiVideoAnalysis {
+	sorted := append([]*AnalysisResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Meta.PublishedAt.Before(sorted[j].Meta.PublishedAt) })
+
+	agg := &MultiVideoAnalysis{WordFreq: map[string]int{}}
+	available := 0
+	type authorStats struct {
+		RecurringCommenter
+		videos map[string]bool
+	}
+	authors := map[string]*authorStats{}
+	for _, res := range sorted {
+		agg.Videos = append(agg.Videos, VideoSummary{
+			VideoID:     res.VideoID,
+			Title:       res.Meta.Title,
+			Thumbnail:   res.Meta.Thumbnail,
+			PublishedAt: res.Meta.PublishedAt,
+			PosCount:    res.PosCount,
+			NegCount:    res.NegCount,
+			NeuCount:    res.NeuCount,
+			TotalCount:  res.TotalCount,
+			Shares:      res.Shares,
+			TopKeywords: res.TopKeywords,
+		})
+		agg.PosCount += res.PosCount
+		agg.NegCount += res.NegCount
+		agg.NeuCount += res.NeuCount
+		agg.TotalCount += res.TotalCount
+		available += res.Sampling.AvailableCount
+		for w, n := range res.WordFreq {
+			agg.WordFreq[w] += n
+		}
+		for i, c := range res.Comments {
+			if c.Author == "" || i >= len(res.Labels) {
+				continue
+			}
+			a := authors[c.Author]
+			if a == nil {
+				a = &authorStats{RecurringCommenter: RecurringCommenter{Author: c.Author}, videos: map[string]bool{}}
+				authors[c.Author] = a
+			}
+			a.videos[res.VideoID] = true
+			a.CommentCount++
+			switch res.Labels[i] {
+			case "긍정":
+				a.PosCount++
+			case "부정":
+				a.NegCount++
+			case "중립":
+				a.NeuCount++
+			}
+		}
+	}
+	agg.Shares = SentimentShares(agg.PosCount, agg.NegCount, agg.NeuCount, available)
+	agg.TopKeywords = TopNWords(agg.WordFreq, 5)
+
+	for _, a := range authors {
+		if len(a.videos) < 2 {
+			continue
+		}
+		a.VideoCount = len(a.videos)
+		agg.RecurringCommenters = append(agg.RecurringCommenters, a.RecurringCommenter)
+	}
+	sort.Slice(agg.RecurringCommenters, func(i, j int) bool {
+		a, b := agg.RecurringCommenters[i], agg.RecurringCommenters[j]
+		if a.VideoCount != b.VideoCount {
+			return a.VideoCount > b.VideoCount
+		}
+		if a.CommentCount != b.CommentCount {
+			return a.CommentCount > b.CommentCount
+		}
+		return a.Author < b.Author
+	})
+	if len(agg.RecurringCommenters) > maxRecurringCommenters {
+		agg.RecurringCommenters = agg.RecurringCommenters[:maxRecurringCommenters]
+	}
+
+	for _, w := range TopNWords(agg.WordFreq, keywordTrendWords) {
+		trend := KeywordTrend{Word: w, Total: agg.WordFreq[w], Counts: make([]int, len(sorted))}
+		for i, res := range sorted {
+			trend.Counts[i] = res.WordFreq[w]
+		}
+		agg.KeywordTrends = append(agg.KeywordTrends, trend)
+	}
+	return agg
+}
+
+// GenerateVideoSentimentChart: 영상별 긍정/부정/중립 비율(%) 묶음 막대 차트 생성 (업로드 순서)
+func GenerateVideoSentimentChart(agg *MultiVideoAnalysis, filePath string) error {
+	names := make([]string, 0, len(agg.Videos))
+	shares := make([][]ShareEstimate, 0, len(agg.Videos))
+	for _, v := range agg.Videos {
+		names = append(names, videoChartName(v.Title, v.VideoID))
+		shares = append(shares, v.Shares)
+	}
+	return generateSentimentBars(names, shares, filePath)
+}
+
+// GenerateKeywordTrendChart: 상위 키워드의 영상별 빈도 변화 꺾은선 차트 생성
+func GenerateKeywordTrendChart(agg *MultiVideoAnalysis, filePath string) error {
+	xAxis := make([]string, 0, len(agg.Videos))
+	for _, v := range agg.Videos {
+		xAxis = append(xAxis, videoChartName(v.Title, v.VideoID))
+	}
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "영상별 키워드 추이"}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: opts.Bool(true), Trigger: "axis"}),
+	)
+	line.SetXAxis(xAxis)
+	for _, t := range agg.KeywordTrends {
+		data := make([]opts.LineData, 0, len(t.Counts))
+		for _, n := range t.Counts {
+			data = append(data, opts.LineData{Value: n})
+		}
+		line.AddSeries(t.Word, data)
+	}
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return line.Render(f)
+}
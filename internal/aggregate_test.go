@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAggregateAnalyses(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := &AnalysisResult{
+		VideoID:  "bbbbbbbbbbb",
+		Meta:     VideoMeta{PublishedAt: day.AddDate(0, 0, 7)},
+		Comments: []Comment{{Author: "팬1"}, {Author: "팬2"}},
+		Labels:   []string{"부정", "긍정"},
+		WordFreq: map[string]int{"노래": 1, "춤": 4},
+		PosCount: 1, NegCount: 1, TotalCount: 2,
+	}
+	older := &AnalysisResult{
+		VideoID:  "aaaaaaaaaaa",
+		Meta:     VideoMeta{PublishedAt: day},
+		Comments: []Comment{{Author: "팬1"}, {Author: "팬3"}, {Author: "팬1"}},
+		Labels:   []string{"긍정", "중립", "긍정"},
+		WordFreq: map[string]int{"노래": 5},
+		PosCount: 2, NeuCount: 1, TotalCount: 3,
+	}
+	agg := AggregateAnalyses([]*AnalysisResult{newer, older})
+
+	if agg.Videos[0].VideoID != older.VideoID {
+		t.Errorf("영상이 업로드 순으로 정렬되지 않음: %+v", agg.Videos)
+	}
+	if agg.PosCount != 3 || agg.NegCount != 1 || agg.NeuCount != 1 || agg.TotalCount != 5 {
+		t.Errorf("긍정/부정/중립/전체 = %d/%d/%d/%d", agg.PosCount, agg.NegCount, agg.NeuCount, agg.TotalCount)
+	}
+	want := []RecurringCommenter{{Author: "팬1", VideoCount: 2, CommentCount: 3, PosCount: 2, NegCount: 1}}
+	if !reflect.DeepEqual(agg.RecurringCommenters, want) {
+		t.Errorf("반복 댓글 작성자 = %+v", agg.RecurringCommenters)
+	}
+	if len(agg.KeywordTrends) != 2 || agg.KeywordTrends[0].Word != "노래" || !reflect.DeepEqual(agg.KeywordTrends[0].Counts, []int{5, 1}) {
+		t.Errorf("키워드 추이 = %+v", agg.KeywordTrends)
+	}
+}
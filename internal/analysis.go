@@ -0,0 +1,296 @@
+package internal
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// AnalysisOptions: 분석 파이프라인 설정
+type AnalysisOptions struct {
+	MaxComments    int      // 분석할 최대 댓글 수 (초과시 랜덤 샘플링)
+	Aspects        bool     // 대상(측면)별 감성분석 수행 여부
+	Emotions       bool     // 세부 감정 분류 및 비꼼 감지 여부
+	KeepFlagged    bool     // 스팸/유해 댓글을 분석에서 제외하지 않고 통계만 보고
+	Stopwords      []string // 기본/채널 불용어 외에 추가로 제외할 단어
+	Phrases        bool     // 2~3단어 연어를 단어 빈도에 합칠지 여부
+	SquashRepeats  bool     // 반복 글자 줄이기 ("ㅋㅋㅋㅋ" → "ㅋㅋ")
+	NormalizeEmoji bool     // 이모지 변형/피부색 차이 통일
+	Offline        bool     // OpenAI 없이 이모지/신조어/단어 사전으로만 감성분석
+	Languages      []string // 분석할 언어 코드 (비어 있으면 전체)
+	TimelineUnit   string   // 감성 타임라인 구간 단위 (hour, day, week, 비어 있으면 자동)
+	KeywordScorer  string   // 키워드 점수 방식 (freq, tfidf, llr)
+	Topics         bool     // 댓글 토픽 군집 여부
+	TopicCount     int      // 토픽 수 (0이면 댓글 수에 따라 자동)
+	UserID         string   // 채널 불용어를 적용할 사용자 (채널 불용어는 사용자별로 저장)
+}
+
+// DefaultAnalysisOptions: 기본 분석 설정 (댓글 100개, 빈도 기준 키워드, 연어 추출)
+func DefaultAnalysisOptions() AnalysisOptions {
+	return AnalysisOptions{MaxComments: 100, KeywordScorer: KeywordFreq, Phrases: true}
+}
+
+// AnalysisResult: 영상 1개에 대한 댓글 분석 결과
+type AnalysisResult struct {
+	VideoID       string                      `json:"videoId"`
+	Meta          VideoMeta                   `json:"meta"`
+	Comments      []Comment                   `json:"comments"`
+	Sentiments    []SentimentResult           `json:"sentiments"` // Comments와 같은 순서
+	Labels        []string                    `json:"labels"`     // Sentiments의 라벨만 모은 것
+	WordFreq      map[string]int              `json:"wordFreq"`
+	TopKeywords   []string                    `json:"topKeywords"`
+	KeywordScorer string                      `json:"keywordScorer"`
+	KeywordScores map[string]float64          `json:"keywordScores,omitempty"` // freq가 아닐 때 상위 키워드 점수
+	PosCount      int                         `json:"posCount"`
+	NegCount      int                         `json:"negCount"`
+	NeuCount      int                         `json:"neuCount"`
+	TotalCount    int                         `json:"totalCount"`
+	Insight       Insight                     `json:"insight"`
+	Aspects       []AspectSummary             `json:"aspects,omitempty"`
+	Emotions      map[string]int              `json:"emotions,omitempty"` // 감정별 댓글 수
+	SarcasmCount  int                         `json:"sarcasmCount"`
+	Moderation    ModerationSummary           `json:"moderation"`
+	EmojiStats    []SignalCount               `json:"emojiStats"`
+	SlangStats    []SignalCount               `json:"slangStats"`
+	Languages     []LanguageBreakdown         `json:"languages"` // 언어별 댓글 수와 감성 분포
+	Timeline      SentimentTimeline           `json:"timeline"`
+	Weighted      WeightedDistribution        `json:"weighted"`   // 좋아요/답글 가중 감성 분포
+	TopEngaged    map[string][]EngagedComment `json:"topEngaged"` // 감성별 공감 많은 댓글
+	Sampling      SamplingInfo                `json:"sampling"`
+	Shares        []ShareEstimate             `json:"shares"` // 긍정/부정/중립 비율과 95% 신뢰구간
+	Topics        TopicResult                 `json:"topics"`
+	FallbackCount int                         `json:"fallbackCount"` // OpenAI 실패로 사전 기반 점수를 쓴 댓글 수
+}
+
+// EmotionLabels: 댓글별 세부 감정 (감정 분석을 하지 않았으면 nil)
+func (a *AnalysisResult) EmotionLabels() []string {
+	if a.Emotions == nil {
+		return nil
+	}
+	labels := make([]string, 0, len(a.Sentiments))
+	for _, s := range a.Sentiments {
+		if s.Emotion != "" {
+			labels = append(labels, s.Emotion)
+		}
+	}
+	return labels
+}
+
+// EligibleAuthors: 모임 참가 자격이 있는 긍정 댓글 작성자 (비꼬는 댓글 제외, 중복 제거)
+func (a *AnalysisResult) EligibleAuthors() []string {
+	seen := map[string]bool{}
+	authors := []string{}
+	for i, s := range a.Sentiments {
+		if !s.Eligible() || i >= len(a.Comments) {
+			continue
+		}
+		author := a.Comments[i].Author
+		if author == "" || seen[author] {
+			continue
+		}
+		seen[author] = true
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+// Share: 감성 라벨의 비율 추정치 (없으면 빈 값)
+func (a *AnalysisResult) Share(label string) ShareEstimate {
+	for _, s := range a.Shares {
+		if s.Label == label {
+			return s
+		}
+	}
+	return ShareEstimate{Label: label}
+}
+
+// RunAnalysis: 댓글 수집 → 텍스트 정규화/언어 감지 → 스팸/유해 필터링 → 샘플링 → 감성(및 측면) 분석 → 단어 빈도 → 인사이트 생성
+func RunAnalysis(ctx context.Context, videoID string, opt AnalysisOptions) (*AnalysisResult, error) {
+	meta, _ := FetchVideoMeta(videoID)
+
+	comments, err := FetchComments(videoID)
+	if err != nil {
+		return nil, err
+	}
+	fetched := len(comments)
+	for i := range comments {
+		if opt.SquashRepeats {
+			comments[i].Text = SquashRepeatedChars(comments[i].Text)
+		}
+		if opt.NormalizeEmoji {
+			comments[i].Text = NormalizeEmoji(comments[i].Text)
+		}
+		if comments[i].Language == "" {
+			comments[i].Language = DetectLanguage(comments[i].Text)
+		}
+	}
+	comments = FilterByLanguage(comments, opt.Languages)
+	moderation := ModerateComments(comments)
+	if !opt.KeepFlagged {
+		comments = moderation.Kept
+	}
+	comments = sampleComments(comments, opt.MaxComments)
+	comments = moderation.ApplyOpenAIModeration(comments, !opt.KeepFlagged)
+
+	res := &AnalysisResult{VideoID: videoID, Meta: meta, Comments: comments}
+	res.Moderation = moderation.Summary(fetched, !opt.KeepFlagged)
+	var aspectMentions [][]AspectMention
+	res.Sentiments, aspectMentions = analyzeComments(comments, opt)
+	res.Labels = make([]string, len(res.Sentiments))
+	for i, s := range res.Sentiments {
+		res.Labels[i] = s.Label
+	}
+	if !opt.Offline {
+		res.FallbackCount = countSentimentSource(res.Sentiments, SourceOffline)
+	}
+
+	// 댓글 텍스트 배열
+	commentTexts := make([]string, 0, len(comments))
+	for _, c := range comments {
+		commentTexts = append(commentTexts, c.Text)
+	}
+	channelStop, _ := GetChannelStopwords(ctx, opt.UserID, meta.ChannelID)
+	extraStop := append(channelStop, opt.Stopwords...)
+	// 단어 빈도에는 댓글마다 감지된 언어의 불용어만 적용하고,
+	// 연어 경계와 토픽 키워드에는 등장한 모든 언어의 불용어를 사용
+	stop := Stopwords(commentLanguages(comments), extraStop)
+	freq := CountWordsByLanguage(comments, extraStop)
+	if opt.Phrases {
+		freq = MergePhrases(freq, ExtractPhrases(commentTexts, DefaultPhraseOptions(), stop))
+	}
+	res.WordFreq = RemoveStopwords(freq, Stopwords(nil, extraStop))
+
+	// 감성분석 결과 개수 계산
+	res.TotalCount = len(res.Labels)
+	for _, label := range res.Labels {
+		switch label {
+		case "긍정":
+			res.PosCount++
+		case "부정":
+			res.NegCount++
+		case "중립":
+			res.NeuCount++
+		}
+	}
+	res.EmojiStats, res.SlangStats = SignalStats(comments)
+	res.Languages = BreakdownByLanguage(comments, res.Labels)
+	res.Weighted = WeightedSentiment(comments, res.Labels)
+	res.TopEngaged = TopEngagedByLabel(comments, res.Labels, topEngagedPerLabel)
+	res.Timeline = BuildSentimentTimeline(comments, res.Labels, meta.PublishedAt, opt.TimelineUnit)
+	res.Sampling = NewSamplingInfo(meta.CommentCount, fetched, len(comments))
+	res.Shares = SentimentShares(res.PosCount, res.NegCount, res.NeuCount, res.Sampling.AvailableCount)
+	if opt.Topics {
+		// 오프라인 모드에서는 임베딩 API 대신 TF-IDF 벡터로 군집
+		res.Topics = ClusterComments(comments, res.Labels, opt.TopicCount, !opt.Offline, stop)
+	}
+	if opt.Aspects {
+		res.Aspects = AggregateAspects(comments, aspectMentions)
+	}
+	if opt.Emotions {
+		res.Emotions = map[string]int{}
+		for _, s := range res.Sentiments {
+			if s.Emotion != "" {
+				res.Emotions[s.Emotion]++
+			}
+			if s.Sarcasm {
+				res.SarcasmCount++
+			}
+		}
+	}
+
+	// 상위 키워드 추출 및 인사이트 요약 (감성 분포와 대표 댓글을 함께 전달)
+	res.KeywordScorer = opt.KeywordScorer
+	if opt.KeywordScorer == KeywordTFIDF || opt.KeywordScorer == KeywordLLR {
+		bg, _ := LoadBackgroundCorpus(ctx, videoID)
+		scores := ScoreKeywords(res.WordFreq, bg, opt.KeywordScorer)
+		res.TopKeywords = TopNScored(scores, 5)
+		res.KeywordScores = make(map[string]float64, len(res.TopKeywords))
+		for _, w := range res.TopKeywords {
+			res.KeywordScores[w] = scores[w]
+		}
+	} else {
+		res.KeywordScorer = KeywordFreq
+		res.TopKeywords = TopNWords(res.WordFreq, 5)
+	}
+	_ = AddToCorpus(ctx, videoID, res.WordFreq)
+	res.Insight, _ = GenerateInsight(InsightInput{
+		Keywords: res.TopKeywords,
+		Comments: comments,
+		Labels:   res.Labels,
+		PosCount: res.PosCount,
+		NegCount: res.NegCount,
+		NeuCount: res.NeuCount,
+	})
+	return res, nil
+}
+
+// commentLanguages: 댓글에 등장한 언어 코드 목록 (한국어/영어 불용어는 항상 포함)
+func commentLanguages(comments []Comment) []string {
+	langs := []string{"ko", "en"}
+	for _, c := range comments {
+		if c.Language != "" && !containsString(langs, c.Language) {
+			langs = append(langs, c.Language)
+		}
+	}
+	return langs
+}
+
+// sampleComments: 댓글이 max개보다 많으면 랜덤 샘플링
+func sampleComments(comments []Comment, max int) []Comment {
+	if max <= 0 || len(comments) <= max {
+		return comments
+	}
+	rand.Seed(time.Now().UnixNano())
+	perm := rand.Perm(len(comments))[:max]
+	sampled := make([]Comment, 0, max)
+	for _, idx := range perm {
+		sampled = append(sampled, comments[idx])
+	}
+	return sampled
+}
+
+// countSentimentSource: 라벨 출처가 source인 댓글 수
+func countSentimentSource(sentiments []SentimentResult, source string) int {
+	n := 0
+	for _, s := range sentiments {
+		if s.Source == source {
+			n++
+		}
+	}
+	return n
+}
+
+// analyzeComments: 댓글별 감성분석(및 측면 추출) 병렬 처리 (최대 5개 동시)
+func analyzeComments(comments []Comment, opt AnalysisOptions) ([]SentimentResult, [][]AspectMention) {
+	sentiments := make([]SentimentResult, len(comments))
+	var aspects [][]AspectMention
+	if opt.Aspects {
+		aspects = make([][]AspectMention, len(comments))
+	}
+	sem := make(chan struct{}, 5) // 동시 5개 제한
+	var wg sync.WaitGroup
+	for i, c := range comments {
+		wg.Add(1)
+		go func(idx int, text string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if opt.Offline {
+				sentiments[idx] = ScoreSentimentOffline(text)
+				return
+			}
+			s, err := AnalyzeSentimentDetail(text, opt.Emotions)
+			if err != nil {
+				// OpenAI 실패시 오프라인 점수로 대체 (Source로 구분해 FallbackCount에 집계)
+				s = ScoreSentimentOffline(text)
+			}
+			sentiments[idx] = s
+			if opt.Aspects {
+				aspects[idx], _ = ExtractAspects(text)
+			}
+		}(i, c.Text)
+	}
+	wg.Wait()
+	return sentiments, aspects
+}
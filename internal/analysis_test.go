@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMeetingEligibilityGate(t *testing.T) {
+	res := &AnalysisResult{
+		Comments: []Comment{
+			{Author: "팬1"}, {Author: "팬2"}, {Author: "비꼬는사람"}, {Author: "팬1"}, {Author: ""}, {Author: "중립러"},
+		},
+		Sentiments: []SentimentResult{
+			{Label: "긍정"}, {Label: "긍정"}, {Label: "긍정", Sarcasm: true}, {Label: "긍정"}, {Label: "긍정"}, {Label: "중립"},
+		},
+	}
+	eligible := res.EligibleAuthors()
+	if want := []string{"팬1", "팬2"}; !reflect.DeepEqual(eligible, want) {
+		t.Fatalf("참가 자격 명단 = %v, 기대값 %v", eligible, want)
+	}
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"팬1", true},
+		{"팬2", true},
+		{"비꼬는사람", false}, // 비꼬는 칭찬은 자격 없음
+		{"중립러", false},
+		{"", false},
+		{"팬", false}, // 부분 일치는 허용하지 않음
+	}
+	for _, tc := range cases {
+		if got := containsString(eligible, tc.name); got != tc.want {
+			t.Errorf("참가 신청 %q 허용 = %v, 기대값 %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAnalysisMatches(t *testing.T) {
+	rec := &AnalysisRecord{ID: "r1", UserID: "u1", VideoID: "dQw4w9WgXcQ"}
+	cases := []struct {
+		name    string
+		rec     *AnalysisRecord
+		videoID string
+		userID  string
+		want    bool
+	}{
+		{"같은 영상, 같은 사용자", rec, "dQw4w9WgXcQ", "u1", true},
+		{"다른 영상", rec, "aaaaaaaaaaa", "u1", false},
+		{"다른 사용자", rec, "dQw4w9WgXcQ", "u2", false},
+		{"기록 없음", nil, "dQw4w9WgXcQ", "u1", false},
+	}
+	for _, tc := range cases {
+		if got := analysisMatches(tc.rec, tc.videoID, tc.userID); got != tc.want {
+			t.Errorf("%s: analysisMatches = %v, 기대값 %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestEligibleAuthors(t *testing.T) {
+	cases := []struct {
+		name       string
+		comments   []Comment
+		sentiments []SentimentResult
+		want       []string
+	}{
+		{"결과 없음", nil, nil, []string{}},
+		{"긍정만 자격", []Comment{{Author: "a"}, {Author: "b"}, {Author: "c"}},
+			[]SentimentResult{{Label: "긍정"}, {Label: "부정"}, {Label: "중립"}}, []string{"a"}},
+		{"비꼬는 칭찬 제외", []Comment{{Author: "a"}, {Author: "b"}},
+			[]SentimentResult{{Label: "긍정", Sarcasm: true, Emotion: "비꼼"}, {Label: "긍정", Emotion: "기쁨"}}, []string{"b"}},
+		{"중복 작성자는 한 번, 처음 순서 유지", []Comment{{Author: "b"}, {Author: "a"}, {Author: "b"}},
+			[]SentimentResult{{Label: "긍정"}, {Label: "긍정"}, {Label: "긍정"}}, []string{"b", "a"}},
+		{"작성자 없음 제외", []Comment{{Author: ""}, {Author: "a"}},
+			[]SentimentResult{{Label: "긍정"}, {Label: "긍정"}}, []string{"a"}},
+		{"댓글보다 결과가 많으면 무시", []Comment{{Author: "a"}},
+			[]SentimentResult{{Label: "긍정"}, {Label: "긍정"}}, []string{"a"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := &AnalysisResult{Comments: tc.comments, Sentiments: tc.sentiments}
+			if got := res.EligibleAuthors(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("EligibleAuthors = %v, 기대값 %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmotionLabels(t *testing.T) {
+	res := &AnalysisResult{Sentiments: []SentimentResult{{Emotion: "기쁨"}, {}, {Emotion: "비꼼"}}}
+	if got := res.EmotionLabels(); got != nil {
+		t.Errorf("감정 분석을 하지 않았는데 %v", got)
+	}
+	res.Emotions = map[string]int{"기쁨": 1, "비꼼": 1}
+	if got, want := res.EmotionLabels(), []string{"기쁨", "비꼼"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EmotionLabels = %v, 기대값 %v", got, want)
+	}
+}
+
+func TestMeetingEligibility(t *testing.T) {
+	linked := Meeting{MeetingID: "m1", AnalysisID: "r1"}
+	eligible := []string{"팬1"}
+	cases := []struct {
+		name    string
+		meeting Meeting
+		loadErr error
+		author  string
+		want    int
+	}{
+		{"분석 기록 없는 모임", Meeting{MeetingID: "m1"}, nil, "아무나", 0},
+		{"명단에 있는 작성자", linked, nil, "팬1", 0},
+		{"명단에 없는 작성자", linked, nil, "아무나", 403},
+		{"기록을 불러오지 못하면 막음", linked, errors.New("삭제된 기록"), "팬1", 500},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var list []string
+			if tc.loadErr == nil {
+				list = eligible
+			}
+			if got, _ := meetingEligibility(tc.meeting, tc.loadErr, list, tc.author); got != tc.want {
+				t.Errorf("응답 코드 %d, 기대값 %d", got, tc.want)
+			}
+		})
+	}
+}
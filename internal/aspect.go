@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 측면 분류 (대상이 어떤 종류인지)
+var aspectCategories = []string{"크리에이터", "편집", "음악", "게스트", "제품", "콘텐츠", "기타"}
+
+// 측면별로 보여줄 예시 댓글 최대 개수
+const aspectMaxExamples = 3
+
+// AspectMention: 댓글 1개에서 추출한 대상과 그 대상에 대한 감성
+type AspectMention struct {
+	Target    string `json:"target"`
+	Category  string `json:"category"`
+	Sentiment string `json:"sentiment"`
+}
+
+// AspectExample: 측면 요약에 붙는 예시 댓글 (Ref는 1부터 시작하는 댓글 번호)
+type AspectExample struct {
+	Ref       int    `json:"ref"`
+	Text      string `json:"text"`
+	Sentiment string `json:"sentiment"`
+}
+
+// AspectSummary: 대상별 감성 집계
+type AspectSummary struct {
+	Target   string          `json:"target"`
+	Category string          `json:"category"`
+	PosCount int             `json:"posCount"`
+	NegCount int             `json:"negCount"`
+	NeuCount int             `json:"neuCount"`
+	Total    int             `json:"total"`
+	Examples []AspectExample `json:"examples"`
+}
+
+type aspectResponse struct {
+	Aspects []AspectMention `json:"aspects"`
+}
+
+func (a *aspectResponse) validate() error {
+	for _, m := range a.Aspects {
+		if strings.TrimSpace(m.Target) == "" {
+			return fmt.Errorf("target이 비어 있음")
+		}
+		if !containsString(aspectCategories, m.Category) {
+			return fmt.Errorf("허용되지 않는 category: %q", m.Category)
+		}
+		if !containsString(sentimentLabels, m.Sentiment) {
+			return fmt.Errorf("허용되지 않는 sentiment: %q", m.Sentiment)
+		}
+	}
+	return nil
+}
+
+var aspectSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"aspects": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"target":    map[string]interface{}{"type": "string"},
+					"category":  map[string]interface{}{"type": "string", "enum": aspectCategories},
+					"sentiment": map[string]interface{}{"type": "string", "enum": sentimentLabels},
+				},
+				"required":             []string{"target", "category", "sentiment"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"aspects"},
+	"additionalProperties": false,
+}
+
+// ExtractAspects: 댓글에서 언급된 대상(크리에이터, 편집, 음악, 게스트, 제품 등)과 대상별 감성 추출
+func ExtractAspects(text string) ([]AspectMention, error) {
+	prompt := "다음 유튜브 댓글에서 반응의 대상(예: 크리에이터, 편집, 음악, 게스트 이름, 제품명)을 찾아 " +
+		"대상별 감성을 '긍정', '부정', '중립' 중 하나로 JSON으로 답해줘. target은 짧은 명사로, category는 " +
+		strings.Join(aspectCategories, ", ") + " 중 하나로. 대상이 없으면 빈 배열.\n댓글: " + text
+	var res aspectResponse
+	if err := chatJSON(prompt, "aspects", aspectSchema, &res, res.validate); err != nil {
+		return nil, err
+	}
+	return res.Aspects, nil
+}
+
+// AggregateAspects: 댓글별 측면 추출 결과를 대상 단위로 집계 (언급 많은 순)
+func AggregateAspects(comments []Comment, mentions [][]AspectMention) []AspectSummary {
+	byKey := map[string]*AspectSummary{}
+	order := []string{}
+	for i, ms := range mentions {
+		for _, m := range ms {
+			key := strings.ToLower(strings.TrimSpace(m.Target))
+			s, ok := byKey[key]
+			if !ok {
+				s = &AspectSummary{Target: strings.TrimSpace(m.Target), Category: m.Category}
+				byKey[key] = s
+				order = append(order, key)
+			}
+			switch m.Sentiment {
+			case "긍정":
+				s.PosCount++
+			case "부정":
+				s.NegCount++
+			case "중립":
+				s.NeuCount++
+			}
+			s.Total++
+			if len(s.Examples) < aspectMaxExamples && i < len(comments) {
+				s.Examples = append(s.Examples, AspectExample{Ref: i + 1, Text: comments[i].Text, Sentiment: m.Sentiment})
+			}
+		}
+	}
+	summaries := make([]AspectSummary, 0, len(order))
+	for _, key := range order {
+		summaries = append(summaries, *byKey[key])
+	}
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Total > summaries[j].Total })
+	return summaries
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAggregateAspects(t *testing.T) {
+	comments := []Comment{{Text: "편집 좋고 음악 별로"}, {Text: "편집 최고"}, {Text: "음악 그냥"}, {Text: "편집 굿"}, {Text: "편집 짱"}}
+	cases := []struct {
+		name     string
+		mentions [][]AspectMention
+		want     []AspectSummary
+	}{
+		{"언급 없음", [][]AspectMention{nil, {}}, []AspectSummary{}},
+		{
+			"대소문자와 공백이 달라도 같은 대상, 언급 많은 순",
+			[][]AspectMention{
+				{{Target: "음악", Category: "음악", Sentiment: "부정"}, {Target: "BGM", Category: "음악", Sentiment: "긍정"}},
+				{{Target: " bgm ", Category: "음악", Sentiment: "긍정"}},
+				{{Target: "Bgm", Category: "음악", Sentiment: "중립"}},
+			},
+			[]AspectSummary{
+				{Target: "BGM", Category: "음악", PosCount: 2, NeuCount: 1, Total: 3, Examples: []AspectExample{
+					{Ref: 1, Text: comments[0].Text, Sentiment: "긍정"},
+					{Ref: 2, Text: comments[1].Text, Sentiment: "긍정"},
+					{Ref: 3, Text: comments[2].Text, Sentiment: "중립"},
+				}},
+				{Target: "음악", Category: "음악", NegCount: 1, Total: 1, Examples: []AspectExample{
+					{Ref: 1, Text: comments[0].Text, Sentiment: "부정"},
+				}},
+			},
+		},
+		{
+			"예시 댓글은 최대 aspectMaxExamples개",
+			[][]AspectMention{
+				{{Target: "편집", Category: "편집", Sentiment: "긍정"}},
+				{{Target: "편집", Category: "편집", Sentiment: "긍정"}},
+				{},
+				{{Target: "편집", Category: "편집", Sentiment: "긍정"}},
+				{{Target: "편집", Category: "편집", Sentiment: "부정"}},
+			},
+			[]AspectSummary{
+				{Target: "편집", Category: "편집", PosCount: 3, NegCount: 1, Total: 4, Examples: []AspectExample{
+					{Ref: 1, Text: comments[0].Text, Sentiment: "긍정"},
+					{Ref: 2, Text: comments[1].Text, Sentiment: "긍정"},
+					{Ref: 4, Text: comments[3].Text, Sentiment: "긍정"},
+				}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AggregateAspects(comments, tc.mentions); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("AggregateAspects = %+v\n기대값 %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractAspects(t *testing.T) {
+	cases := []struct {
+		name    string
+		reply   string
+		want    []AspectMention
+		wantErr bool
+	}{
+		{"정상", `{"aspects":[{"target":"편집","category":"편집","sentiment":"긍정"}]}`, []AspectMention{{Target: "편집", Category: "편집", Sentiment: "긍정"}}, false},
+		{"대상 없음", `{"aspects":[]}`, []AspectMention{}, false},
+		{"허용되지 않는 분류", `{"aspects":[{"target":"편집","category":"영상미","sentiment":"긍정"}]}`, nil, true},
+		{"빈 대상", `{"aspects":[{"target":" ","category":"편집","sentiment":"긍정"}]}`, nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stubChatCompletions(t, nil, tc.reply)
+			got, err := ExtractAspects("편집 좋다")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, 에러 기대 %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ExtractAspects = %+v, 기대값 %+v", got, tc.want)
+			}
+		})
+	}
+}
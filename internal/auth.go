@@ -1,3 +1,38 @@
 package internal
 
 // 이 파일은 추후 JWT 토큰 검증 등 필요시만 사용. 현재는 REST API 방식만 사용.
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// currentUserID: 세션 쿠키의 Firebase ID 토큰에서 사용자 ID(user_id, 없으면 sub) 추출.
+// isAuthenticated와 마찬가지로 서명 검증은 생략 (추후 확장)
+func currentUserID(r *http.Request) string {
+	cookie, err := r.Cookie("session_token")
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(cookie.Value, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		UserID  string `json:"user_id"`
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	if claims.UserID != "" {
+		return claims.UserID
+	}
+	return claims.Subject
+}
@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// 분석 결과 캐시 기본 유지 시간과 동시에 분석하는 최대 영상 수
+const (
+	defaultAnalysisCacheTTL = 10 * time.Minute
+	maxConcurrentAnalyses   = 3
+)
+
+type cachedAnalysis struct {
+	res     *AnalysisResult
+	expires time.Time
+	records map[string]string // 사용자 ID별로 이 결과를 저장한 분석 기록 ID (캐시 적중시 재사용)
+}
+
+var (
+	analysisCacheMu sync.Mutex
+	analysisCache   = map[string]cachedAnalysis{}
+	// 영상 단위 분석 동시 실행 제한 (비교/재생목록/채널 분석이 같은 한도를 공유)
+	analysisSem = make(chan struct{}, maxConcurrentAnalyses)
+	// 같은 영상/옵션을 동시에 요청하면 분석은 한 번만 실행하고 결과를 나눠 씀
+	analysisFlight singleflight.Group
+	// 캐시가 없을 때 실행하는 분석 (테스트에서 교체)
+	runAnalysis = RunAnalysis
+)
+
+// analysisCacheTTL: ANALYSIS_CACHE_TTL(예: 30m)로 캐시 유지 시간 변경, 0이면 캐시 사용 안 함
+func analysisCacheTTL() time.Duration {
+	if v := os.Getenv("ANALYSIS_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultAnalysisCacheTTL
+}
+
+// CachedAnalysis: 같은 영상/옵션의 최근 분석 결과가 있으면 재사용하고, 없으면 동시 실행 한도 안에서 RunAnalysis 실행.
+// 이미 진행 중인 같은 분석이 있으면 새로 실행하지 않고 그 결과를 기다림
+func CachedAnalysis(ctx context.Context, videoID string, opt AnalysisOptions) (*AnalysisResult, error) {
+	key := analysisCacheKey(videoID, opt)
+	ttl := analysisCacheTTL()
+	now := time.Now()
+
+	analysisCacheMu.Lock()
+	if e, ok := analysisCache[key]; ok && now.Before(e.expires) {
+		analysisCacheMu.Unlock()
+		return e.res, nil
+	}
+	for k, e := range analysisCache {
+		if !now.Before(e.expires) {
+			delete(analysisCache, k)
+		}
+	}
+	analysisCacheMu.Unlock()
+
+	ch := analysisFlight.DoChan(key, func() (interface{}, error) {
+		if err := acquireAnalysisSlot(ctx); err != nil {
+			return nil, err
+		}
+		defer releaseAnalysisSlot()
+		res, err := runAnalysis(ctx, videoID, opt)
+		if err != nil {
+			return nil, err
+		}
+		if ttl > 0 {
+			analysisCacheMu.Lock()
+			analysisCache[key] = cachedAnalysis{res: res, expires: time.Now().Add(ttl)}
+			analysisCacheMu.Unlock()
+		}
+		return res, nil
+	})
+	select {
+	case r := <-ch:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*AnalysisResult), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func analysisCacheKey(videoID string, opt AnalysisOptions) string {
+	return fmt.Sprintf("%s|%+v", videoID, opt)
+}
+
+// cachedRecordID: 캐시된 결과 res를 사용자가 이미 저장했으면 그 기록 ID 반환 (없으면 빈 문자열)
+func cachedRecordID(userID string, opt AnalysisOptions, res *AnalysisResult) string {
+	analysisCacheMu.Lock()
+	defer analysisCacheMu.Unlock()
+	if e, ok := analysisCache[analysisCacheKey(res.VideoID, opt)]; ok && e.res == res && time.Now().Before(e.expires) {
+		return e.records[userID]
+	}
+	return ""
+}
+
+// rememberRecordID: 캐시된 결과 res를 저장한 기록 ID를 캐시 항목 옆에 보관 (캐시에 없는 결과면 무시)
+func rememberRecordID(userID string, opt AnalysisOptions, res *AnalysisResult, recordID string) {
+	analysisCacheMu.Lock()
+	defer analysisCacheMu.Unlock()
+	key := analysisCacheKey(res.VideoID, opt)
+	e, ok := analysisCache[key]
+	if !ok || e.res != res {
+		return
+	}
+	if e.records == nil {
+		e.records = map[string]string{}
+	}
+	e.records[userID] = recordID
+	analysisCache[key] = e
+}
+
+// acquireAnalysisSlot: 분석 동시 실행 한도 안에서 자리를 얻을 때까지 대기 (ctx 취소시 에러)
+func acquireAnalysisSlot(ctx context.Context) error {
+	select {
+	case analysisSem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func releaseAnalysisSlot() {
+	<-analysisSem
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedAnalysisDedupesInFlight(t *testing.T) {
+	t.Setenv("ANALYSIS_CACHE_TTL", "0") // 캐시 없이도 동시에 들어온 같은 요청은 한 번만 분석
+	var runs atomic.Int32
+	release := make(chan struct{})
+	orig := runAnalysis
+	defer func() { runAnalysis = orig }()
+	runAnalysis = func(ctx context.Context, videoID string, opt AnalysisOptions) (*AnalysisResult, error) {
+		runs.Add(1)
+		<-release
+		return &AnalysisResult{VideoID: videoID}, nil
+	}
+
+	const callers = 5
+	results := make([]*AnalysisResult, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = CachedAnalysis(context.Background(), "dedupevid01", DefaultAnalysisOptions())
+		}(i)
+	}
+	// 모든 호출이 진행 중인 분석에 합류할 시간을 준 뒤 분석 완료
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := runs.Load(); n != 1 {
+		t.Errorf("분석 %d번 실행, 기대값 1번", n)
+	}
+	for i, res := range results {
+		if res == nil || res != results[0] {
+			t.Errorf("%d번째 호출 결과 %p, 기대값 공유된 결과 %p", i, res, results[0])
+		}
+	}
+}
+
+func TestCachedAnalysisWaiterCanCancel(t *testing.T) {
+	t.Setenv("ANALYSIS_CACHE_TTL", "0")
+	started, release, finished := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	orig := runAnalysis
+	runAnalysis = func(ctx context.Context, videoID string, opt AnalysisOptions) (*AnalysisResult, error) {
+		close(started)
+		<-release
+		close(finished)
+		return &AnalysisResult{VideoID: videoID}, nil
+	}
+	defer func() {
+		// 진행 중인 분석이 끝난 뒤에 원래 함수로 복구
+		close(release)
+		<-finished
+		runAnalysis = orig
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	// 기다리던 요청이 취소되면 분석이 끝나지 않아도 바로 반환
+	if _, err := CachedAnalysis(ctx, "cancelvid01", DefaultAnalysisOptions()); err != context.Canceled {
+		t.Errorf("에러 = %v, 기대값 %v", err, context.Canceled)
+	}
+}
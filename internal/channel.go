@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// 채널 분석 기본/최대 영상 수
+const (
+	defaultChannelVideos = 5
+	maxChannelVideos     = 20
+)
+
+// ChannelInfo: 채널 이름, 썸네일, 업로드 재생목록 ID
+type ChannelInfo struct {
+	ID                string `json:"id"`
+	Title             string `json:"title"`
+	Thumbnail         string `json:"thumbnail"`
+	UploadsPlaylistID string `json:"uploadsPlaylistId"`
+}
+
+// ChannelAnalysis: 채널 최근 영상들의 댓글 분석 결과
+type ChannelAnalysis struct {
+	Channel ChannelInfo `json:"channel"`
+	*MultiVideoAnalysis
+}
+
+// ResolveChannel: 채널 ID, 채널 URL(/channel/, /@핸들, /user/, /c/), @핸들을 채널 정보로 변환
+func ResolveChannel(input string) (ChannelInfo, error) {
+	if strings.TrimSpace(input) == "" {
+		return ChannelInfo{}, fmt.Errorf("채널 URL 또는 @핸들을 입력하세요.")
+	}
+	ref, err := ParseYouTubeRef(input)
+	if err != nil {
+		return ChannelInfo{}, err
+	}
+	switch ref.Kind {
+	case RefChannelID:
+		return fetchChannel("id=" + ref.ID)
+	case RefHandle:
+		return fetchChannel("forHandle=" + url.QueryEscape(ref.ID))
+	case RefUsername:
+		return fetchChannel("forUsername=" + url.QueryEscape(ref.ID))
+	case RefCustomURL:
+		// 사용자 지정 URL은 API로 바로 조회할 수 없어 같은 이름의 핸들로 조회
+		return fetchChannel("forHandle=" + url.QueryEscape("@"+ref.ID))
+	}
+	return ChannelInfo{}, fmt.Errorf("채널 URL 또는 @핸들이 아닙니다: %s", input)
+}
+
+// fetchChannel: channels API를 조건(id=, forHandle=, forUsername=)으로 조회
+func fetchChannel(query string) (ChannelInfo, error) {
+	apiKey := os.Getenv("YOUTUBE_API_KEY")
+	url := fmt.Sprintf("https://www.googleapis.com/youtube/v3/channels?part=snippet,contentDetails&%s&key=%s", query, apiKey)
+	resp, err := http.Get(url)
+	if err != nil {
+		return ChannelInfo{}, err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Title      string `json:"title"`
+				Thumbnails struct {
+					Default struct {
+						URL string `json:"url"`
+					} `json:"default"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+			ContentDetails struct {
+				RelatedPlaylists struct {
+					Uploads string `json:"uploads"`
+				} `json:"relatedPlaylists"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ChannelInfo{}, err
+	}
+	if len(result.Items) == 0 {
+		return ChannelInfo{}, fmt.Errorf("채널을 찾을 수 없음")
+	}
+	item := result.Items[0]
+	return ChannelInfo{
+		ID:                item.ID,
+		Title:             item.Snippet.Title,
+		Thumbnail:         item.Snippet.Thumbnails.Default.URL,
+		UploadsPlaylistID: item.ContentDetails.RelatedPlaylists.Uploads,
+	}, nil
+}
+
+// FetchPlaylistVideoIDs: 재생목록의 영상 ID를 순서대로 최대 max개 조회
+func FetchPlaylistVideoIDs(playlistID string, max int) ([]string, error) {
+	apiKey := os.Getenv("YOUTUBE_API_KEY")
+	ids := make([]string, 0, max)
+	nextPageToken := ""
+	for len(ids) < max {
+		url := fmt.Sprintf("https://www.googleapis.com/youtube/v3/playlistItems?part=contentDetails&playlistId=%s&key=%s&maxResults=50", playlistID, apiKey)
+		if nextPageToken != "" {
+			url += "&pageToken=" + nextPageToken
+		}
+		resp, err := http.Get(url)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Items []struct {
+				ContentDetails struct {
+					VideoID string `json:"videoId"`
+				} `json:"contentDetails"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			if item.ContentDetails.VideoID == "" {
+				continue
+			}
+			ids = append(ids, item.ContentDetails.VideoID)
+			if len(ids) >= max {
+				break
+			}
+		}
+		if result.NextPageToken == "" {
+			break
+		}
+		nextPageToken = result.NextPageToken
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("재생목록에 영상이 없음")
+	}
+	return ids, nil
+}
+
+// AnalyzeChannel: 채널의 최근 업로드 영상 n개를 분석해 영상별/전체 감성, 단골 작성자, 키워드 추이 집계
+func AnalyzeChannel(ctx context.Context, input string, n int, opt AnalysisOptions) (*ChannelAnalysis, error) {
+	if n <= 0 {
+		n = defaultChannelVideos
+	}
+	if n > maxChannelVideos {
+		n = maxChannelVideos
+	}
+	ch, err := ResolveChannel(input)
+	if err != nil {
+		return nil, err
+	}
+	videoIDs, err := FetchPlaylistVideoIDs(ch.UploadsPlaylistID, n)
+	if err != nil {
+		return nil, err
+	}
+	results, failed := AnalyzeVideos(ctx, videoIDs, opt)
+	if len(results) == 0 {
+		return nil, fmt.Errorf("분석할 수 있는 영상이 없음")
+	}
+	agg := AggregateAnalyses(results)
+	agg.FailedVideoIDs = failed
+	return &ChannelAnalysis{Channel: ch, MultiVideoAnalysis: agg}, nil
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// 비교 가능한 영상 수와 공통/고유 키워드 판단에 쓰는 영상별 상위 단어 수
+const (
+	minCompareVideos      = 2
+	maxCompareVideos      = 5
+	compareKeywordPool    = 20
+	compareMaxDistinctive = 10
+)
+
+// InsightDiff: 영상별 인사이트 중 다른 영상과 겹치지 않는 토픽/논쟁점
+type InsightDiff struct {
+	VideoID       string   `json:"videoId"`
+	Title         string   `json:"title"`
+	OverallMood   string   `json:"overallMood"`
+	UniqueTopics  []string `json:"uniqueTopics"`
+	Controversies []string `json:"controversies"`
+}
+
+// CompareResult: 여러 영상 분석 결과와 공통/고유 키워드, 인사이트 차이
+type CompareResult struct {
+	Videos         []*AnalysisResult   `json:"videos"`
+	SharedKeywords []string            `json:"sharedKeywords"`
+	Distinctive    map[string][]string `json:"distinctive"` // 영상 ID별 고유 키워드
+	SharedTopics   []string            `json:"sharedTopics"`
+	InsightDiffs   []InsightDiff       `json:"insightDiffs"`
+}
+
+// CompareVideos: 영상들을 동시 실행 한도 안에서 분석하고 비교 결과 생성 (입력 순서 유지)
+func CompareVideos(ctx context.Context, videoIDs []string, opt AnalysisOptions) (*CompareResult, error) {
+	if len(videoIDs) < minCompareVideos || len(videoIDs) > maxCompareVideos {
+		return nil, fmt.Errorf("비교할 영상은 %d~%d개여야 합니다.", minCompareVideos, maxCompareVideos)
+	}
+	results := make([]*AnalysisResult, len(videoIDs))
+	errs := make([]error, len(videoIDs))
+	var wg sync.WaitGroup
+	for i, id := range videoIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			results[i], errs[i] = CachedAnalysis(ctx, id, opt)
+		}(i, id)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("영상 %s 분석 실패: %w", videoIDs[i], err)
+		}
+	}
+	return BuildComparison(results), nil
+}
+
+// BuildComparison: 분석 결과들의 공통 키워드(모든 영상 상위 단어에 등장), 고유 키워드, 인사이트 차이 계산
+func BuildComparison(results []*AnalysisResult) *CompareResult {
+	cmp := &CompareResult{Videos: results, Distinctive: map[string][]string{}}
+	pools := make([][]string, len(results))
+	for i, res := range results {
+		pools[i] = TopNWords(res.WordFreq, compareKeywordPool)
+	}
+	cmp.SharedKeywords, cmp.Distinctive = splitShared(results, pools, compareMaxDistinctive)
+
+	topics := make([][]string, len(results))
+	for i, res := range results {
+		topics[i] = res.Insight.MainTopics
+	}
+	var uniqueTopics map[string][]string
+	cmp.SharedTopics, uniqueTopics = splitShared(results, topics, 0)
+	for _, res := range results {
+		cmp.InsightDiffs = append(cmp.InsightDiffs, InsightDiff{
+			VideoID:       res.VideoID,
+			Title:         res.Meta.Title,
+			OverallMood:   res.Insight.OverallMood,
+			UniqueTopics:  uniqueTopics[res.VideoID],
+			Controversies: res.Insight.Controversies,
+		})
+	}
+	return cmp
+}
+
+// splitShared: 영상별 단어 목록에서 모든 영상에 공통인 단어와 한 영상에만 있는 단어를 분리 (limit이 0이면 제한 없음)
+func splitShared(results []*AnalysisResult, lists [][]string, limit int) ([]string, map[string][]string) {
+	seenIn := map[string]int{}
+	for _, list := range lists {
+		seen := map[string]bool{}
+		for _, w := range list {
+			w = strings.TrimSpace(w)
+			if w != "" && !seen[w] {
+				seenIn[w]++
+				seen[w] = true
+			}
+		}
+	}
+	shared := []string{}
+	if len(lists) > 0 {
+		for _, w := range lists[0] {
+			if seenIn[strings.TrimSpace(w)] == len(lists) {
+				shared = append(shared, strings.TrimSpace(w))
+			}
+		}
+	}
+	unique := map[string][]string{}
+	for i, list := range lists {
+		words := []string{}
+		for _, w := range list {
+			w = strings.TrimSpace(w)
+			if seenIn[w] == 1 && (limit == 0 || len(words) < limit) {
+				words = append(words, w)
+			}
+		}
+		unique[results[i].VideoID] = words
+	}
+	return shared, unique
+}
+
+// GenerateCompareChart: 영상별 긍정/부정/중립 비율(%) 묶음 막대 차트 생성
+func GenerateCompareChart(results []*AnalysisResult, filePath string) error {
+	names := make([]string, 0, len(results))
+	shares := make([][]ShareEstimate, 0, len(results))
+	for _, res := range results {
+		names = append(names, videoChartName(res.Meta.Title, res.VideoID))
+		shares = append(shares, res.Shares)
+	}
+	return generateSentimentBars(names, shares, filePath)
+}
+
+// videoChartName: 차트 축에 쓸 영상 이름 (제목이 없으면 영상 ID)
+func videoChartName(title, videoID string) string {
+	if title == "" {
+		return videoID
+	}
+	return truncateRunes(title, 20)
+}
+
+// generateSentimentBars: 항목별 감성 비율(shares[i]는 names[i]의 긍정/부정/중립)을 묶음 막대 차트로 저장
+func generateSentimentBars(names []string, shares [][]ShareEstimate, filePath string) error {
+	series := map[string][]opts.BarData{}
+	for _, ss := range shares {
+		for _, label := range sentimentLabels {
+			percent := 0
+			for _, s := range ss {
+				if s.Label == label {
+					percent = s.Percent
+				}
+			}
+			series[label] = append(series[label], opts.BarData{Value: percent})
+		}
+	}
+	bar := charts.NewBar()
+	bar.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "영상별 감성 비율(%)"}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: opts.Bool(true), Trigger: "axis"}),
+	)
+	bar.SetXAxis(names)
+	for _, label := range sentimentLabels {
+		bar.AddSeries(label, series[label])
+	}
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return bar.Render(f)
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildComparison(t *testing.T) {
+	a := &AnalysisResult{
+		VideoID:  "aaaaaaaaaaa",
+		WordFreq: map[string]int{"노래": 5, "가사": 3, "뮤비": 2},
+		Insight:  Insight{MainTopics: []string{"노래", "뮤비"}},
+	}
+	b := &AnalysisResult{
+		VideoID:  "bbbbbbbbbbb",
+		WordFreq: map[string]int{"노래": 4, "춤": 3},
+		Insight:  Insight{MainTopics: []string{"노래", "춤"}},
+	}
+	cmp := BuildComparison([]*AnalysisResult{a, b})
+	if !reflect.DeepEqual(cmp.SharedKeywords, []string{"노래"}) {
+		t.Errorf("공통 키워드 = %v", cmp.SharedKeywords)
+	}
+	if !reflect.DeepEqual(cmp.Distinctive[a.VideoID], []string{"가사", "뮤비"}) {
+		t.Errorf("a 영상 고유 키워드 = %v", cmp.Distinctive[a.VideoID])
+	}
+	if !reflect.DeepEqual(cmp.Distinctive[b.VideoID], []string{"춤"}) {
+		t.Errorf("b 영상 고유 키워드 = %v", cmp.Distinctive[b.VideoID])
+	}
+	if !reflect.DeepEqual(cmp.SharedTopics, []string{"노래"}) {
+		t.Errorf("공통 토픽 = %v", cmp.SharedTopics)
+	}
+	if len(cmp.InsightDiffs) != 2 || !reflect.DeepEqual(cmp.InsightDiffs[1].UniqueTopics, []string{"춤"}) {
+		t.Errorf("인사이트 차이 = %+v", cmp.InsightDiffs)
+	}
+}
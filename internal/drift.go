@@ -0,0 +1,242 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// 재분석 기본 주기와 부정 비율 경고 기본 임계값(%)
+const (
+	defaultDriftInterval     = 6 * time.Hour
+	defaultDriftNegThreshold = 40
+)
+
+// SentimentSnapshot: 특정 시점의 영상 댓글 감성 분포 (sentimentSnapshots 컬렉션)
+type SentimentSnapshot struct {
+	VideoID    string `firestore:"videoId" json:"videoId"`
+	TakenAt    int64  `firestore:"takenAt" json:"takenAt"`
+	PosCount   int    `firestore:"posCount" json:"posCount"`
+	NegCount   int    `firestore:"negCount" json:"negCount"`
+	NeuCount   int    `firestore:"neuCount" json:"neuCount"`
+	TotalCount int    `firestore:"totalCount" json:"totalCount"`
+	PosPercent int    `firestore:"posPercent" json:"posPercent"`
+	NegPercent int    `firestore:"negPercent" json:"negPercent"`
+	NeuPercent int    `firestore:"neuPercent" json:"neuPercent"`
+}
+
+// NewSentimentSnapshot: 분석 결과로 스냅샷 생성
+func NewSentimentSnapshot(res *AnalysisResult, takenAt time.Time) SentimentSnapshot {
+	return SentimentSnapshot{
+		VideoID:    res.VideoID,
+		TakenAt:    takenAt.Unix(),
+		PosCount:   res.PosCount,
+		NegCount:   res.NegCount,
+		NeuCount:   res.NeuCount,
+		TotalCount: res.TotalCount,
+		PosPercent: res.Share("긍정").Percent,
+		NegPercent: res.Share("부정").Percent,
+		NeuPercent: res.Share("중립").Percent,
+	}
+}
+
+// driftInterval: DRIFT_INTERVAL(예: 3h)로 재분석 주기 변경, 0이면 스케줄러 끔
+func driftInterval() time.Duration {
+	if v := os.Getenv("DRIFT_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultDriftInterval
+}
+
+// driftNegThreshold: DRIFT_NEG_THRESHOLD(부정 비율 %)로 경고 임계값 변경
+func driftNegThreshold() int {
+	if n, err := strconv.Atoi(os.Getenv("DRIFT_NEG_THRESHOLD")); err == nil && n > 0 && n <= 100 {
+		return n
+	}
+	return defaultDriftNegThreshold
+}
+
+// StartDriftScheduler: 진행 중인 모임의 영상을 주기적으로 재분석하는 백그라운드 작업 시작 (ctx 취소시 종료)
+func StartDriftScheduler(ctx context.Context) {
+	interval := driftInterval()
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := RunDriftCheck(ctx); err != nil {
+					log.Printf("감성 변화 재분석 실패: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// 모임 날짜로 받는 형식 (RFC3339, datetime-local, date)
+var meetingDateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+// meetingDatePassed: 모임 날짜가 now 기준으로 지났는지 (날짜만 있으면 그날이 끝난 뒤, 해석할 수 없으면 false)
+func meetingDatePassed(date string, now time.Time) bool {
+	for _, layout := range meetingDateLayouts {
+		t, err := time.ParseInLocation(layout, date, time.Local)
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			t = t.AddDate(0, 0, 1)
+		}
+		return now.After(t)
+	}
+	return false
+}
+
+// needsDriftCheck: 재분석 대상 모임인지 (진행 중이고 모임 날짜가 지나지 않은 모임)
+func needsDriftCheck(m Meeting, now time.Time) bool {
+	return m.Status == "active" && m.MeetingID != "" && !meetingDatePassed(m.MeetingDate, now)
+}
+
+// driftAlert: 스냅샷의 부정 비율이 임계값(%) 이상인지
+func driftAlert(snap SentimentSnapshot, threshold int) bool {
+	return snap.TotalCount > 0 && snap.NegPercent >= threshold
+}
+
+// RunDriftCheck: 진행 중(active)이고 날짜가 지나지 않은 모임의 영상마다 재분석해 스냅샷을 저장하고,
+// 부정 비율이 임계값 이상이면 모임에 경고 표시 (모임 하나의 저장 실패는 기록만 하고 다음 모임 진행)
+func RunDriftCheck(ctx context.Context) error {
+	meetings, err := GetMeetings(ctx)
+	if err != nil {
+		return err
+	}
+	threshold := driftNegThreshold()
+	now := time.Now()
+	snapshots := map[string]SentimentSnapshot{}
+	for _, m := range meetings {
+		if !needsDriftCheck(m, now) {
+			continue
+		}
+		videoID := m.VideoID
+		if videoID == "" {
+			videoID = ParseVideoID(m.YoutubeUrl)
+		}
+		if videoID == "" {
+			continue
+		}
+		snap, ok := snapshots[videoID]
+		if !ok {
+			// 캐시를 거치지 않고 새로 분석하되 동시 실행 한도는 공유
+			if err := acquireAnalysisSlot(ctx); err != nil {
+				return err
+			}
+			res, err := RunAnalysis(ctx, videoID, DefaultAnalysisOptions())
+			releaseAnalysisSlot()
+			if err != nil {
+				log.Printf("영상 %s 재분석 실패: %v", videoID, err)
+				continue
+			}
+			snap = NewSentimentSnapshot(res, time.Now())
+			if err := SaveSentimentSnapshot(ctx, snap); err != nil {
+				log.Printf("영상 %s 감성 스냅샷 저장 실패: %v", videoID, err)
+			}
+			snapshots[videoID] = snap
+		}
+		alert := driftAlert(snap, threshold)
+		if alert && !m.DriftAlert {
+			log.Printf("모임 %s(생성자 %s): 부정 비율 %d%%가 임계값 %d%% 이상", m.MeetingID, m.CreatorID, snap.NegPercent, threshold)
+		}
+		if err := UpdateMeetingDrift(ctx, m.MeetingID, alert, snap.NegPercent, snap.TakenAt); err != nil {
+			log.Printf("모임 %s 경고 상태 갱신 실패: %v", m.MeetingID, err)
+		}
+	}
+	return nil
+}
+
+// SaveSentimentSnapshot: 감성 스냅샷 저장
+func SaveSentimentSnapshot(ctx context.Context, snap SentimentSnapshot) error {
+	_, _, err := firestoreClient.Collection("sentimentSnapshots").Add(ctx, snap)
+	return err
+}
+
+// GetSentimentSnapshots: 영상의 감성 스냅샷을 시간 순으로 조회
+// (Where+OrderBy 복합 색인이 필요 없도록 정렬은 조회 후에 함)
+func GetSentimentSnapshots(ctx context.Context, videoID string) ([]SentimentSnapshot, error) {
+	docs, err := firestoreClient.Collection("sentimentSnapshots").Where("videoId", "==", videoID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	snaps := make([]SentimentSnapshot, 0, len(docs))
+	for _, doc := range docs {
+		var s SentimentSnapshot
+		if err := doc.DataTo(&s); err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, s)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].TakenAt < snaps[j].TakenAt })
+	return snaps, nil
+}
+
+// UpdateMeetingDrift: 모임의 부정 비율 경고 상태 갱신
+func UpdateMeetingDrift(ctx context.Context, meetingID string, alert bool, negPercent int, checkedAt int64) error {
+	docs, err := firestoreClient.Collection("meetings").Where("meetingId", "==", meetingID).Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return fmt.Errorf("모임 %s를 찾을 수 없음", meetingID)
+	}
+	_, err = docs[0].Ref.Update(ctx, []firestore.Update{
+		{Path: "driftAlert", Value: alert},
+		{Path: "negativePercent", Value: negPercent},
+		{Path: "driftCheckedAt", Value: checkedAt},
+	})
+	return err
+}
+
+// GenerateDriftChart: 재분석 시점별 긍정/부정/중립 비율(%) 변화 꺾은선 차트 생성 (임계값 기준선 포함)
+func GenerateDriftChart(snaps []SentimentSnapshot, filePath string) error {
+	xAxis := make([]string, 0, len(snaps))
+	pos := make([]opts.LineData, 0, len(snaps))
+	neg := make([]opts.LineData, 0, len(snaps))
+	neu := make([]opts.LineData, 0, len(snaps))
+	limit := make([]opts.LineData, 0, len(snaps))
+	threshold := driftNegThreshold()
+	for _, s := range snaps {
+		xAxis = append(xAxis, time.Unix(s.TakenAt, 0).Format("01-02 15:04"))
+		pos = append(pos, opts.LineData{Value: s.PosPercent})
+		neg = append(neg, opts.LineData{Value: s.NegPercent})
+		neu = append(neu, opts.LineData{Value: s.NeuPercent})
+		limit = append(limit, opts.LineData{Value: threshold})
+	}
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "댓글 감성 변화(%)"}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: opts.Bool(true), Trigger: "axis"}),
+	)
+	line.SetXAxis(xAxis)
+	line.AddSeries("긍정", pos)
+	line.AddSeries("부정", neg)
+	line.AddSeries("중립", neu)
+	line.AddSeries("부정 경고 기준", limit, charts.WithLineStyleOpts(opts.LineStyle{Type: "dashed"}))
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return line.Render(f)
+}
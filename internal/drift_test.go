@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSentimentSnapshot(t *testing.T) {
+	res := &AnalysisResult{VideoID: "vid", PosCount: 5, NegCount: 3, NeuCount: 2, TotalCount: 10}
+	res.Shares = SentimentShares(res.PosCount, res.NegCount, res.NeuCount, 0)
+	takenAt := time.Unix(1700000000, 0)
+	snap := NewSentimentSnapshot(res, takenAt)
+	want := SentimentSnapshot{
+		VideoID: "vid", TakenAt: 1700000000,
+		PosCount: 5, NegCount: 3, NeuCount: 2, TotalCount: 10,
+		PosPercent: 50, NegPercent: 30, NeuPercent: 20,
+	}
+	if snap != want {
+		t.Errorf("스냅샷 = %+v, 기대값 %+v", snap, want)
+	}
+}
+
+func TestDriftEnv(t *testing.T) {
+	intervals := []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", defaultDriftInterval},
+		{"3h", 3 * time.Hour},
+		{"0", 0}, // 스케줄러 끔
+		{"잘못된값", defaultDriftInterval},
+	}
+	for _, tc := range intervals {
+		t.Setenv("DRIFT_INTERVAL", tc.env)
+		if got := driftInterval(); got != tc.want {
+			t.Errorf("DRIFT_INTERVAL=%q 주기 %v, 기대값 %v", tc.env, got, tc.want)
+		}
+	}
+	thresholds := []struct {
+		env  string
+		want int
+	}{
+		{"", defaultDriftNegThreshold},
+		{"25", 25},
+		{"100", 100},
+		{"0", defaultDriftNegThreshold},
+		{"150", defaultDriftNegThreshold},
+		{"x", defaultDriftNegThreshold},
+	}
+	for _, tc := range thresholds {
+		t.Setenv("DRIFT_NEG_THRESHOLD", tc.env)
+		if got := driftNegThreshold(); got != tc.want {
+			t.Errorf("DRIFT_NEG_THRESHOLD=%q 임계값 %d, 기대값 %d", tc.env, got, tc.want)
+		}
+	}
+}
+
+func TestDriftAlert(t *testing.T) {
+	cases := []struct {
+		snap SentimentSnapshot
+		want bool
+	}{
+		{SentimentSnapshot{TotalCount: 10, NegPercent: 40}, true},
+		{SentimentSnapshot{TotalCount: 10, NegPercent: 39}, false},
+		{SentimentSnapshot{TotalCount: 10, NegPercent: 80}, true},
+		{SentimentSnapshot{TotalCount: 0, NegPercent: 0}, false}, // 댓글이 없으면 경고하지 않음
+	}
+	for _, tc := range cases {
+		if got := driftAlert(tc.snap, 40); got != tc.want {
+			t.Errorf("driftAlert(부정 %d%%, 댓글 %d개) = %v, 기대값 %v", tc.snap.NegPercent, tc.snap.TotalCount, got, tc.want)
+		}
+	}
+}
+
+func TestNeedsDriftCheck(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	cases := []struct {
+		name string
+		m    Meeting
+		want bool
+	}{
+		{"날짜 없음", Meeting{MeetingID: "m", Status: "active"}, true},
+		{"다가올 모임", Meeting{MeetingID: "m", Status: "active", MeetingDate: "2024-05-20T19:00"}, true},
+		{"오늘 모임(날짜만)", Meeting{MeetingID: "m", Status: "active", MeetingDate: "2024-05-10"}, true},
+		{"지난 모임(날짜만)", Meeting{MeetingID: "m", Status: "active", MeetingDate: "2024-05-09"}, false},
+		{"지난 모임(시각)", Meeting{MeetingID: "m", Status: "active", MeetingDate: "2024-05-10T09:00"}, false},
+		{"지난 모임(RFC3339)", Meeting{MeetingID: "m", Status: "active", MeetingDate: "2024-05-01T09:00:00+09:00"}, false},
+		{"해석할 수 없는 날짜", Meeting{MeetingID: "m", Status: "active", MeetingDate: "다음 주"}, true},
+		{"종료된 모임", Meeting{MeetingID: "m", Status: "closed"}, false},
+		{"ID 없음", Meeting{Status: "active"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := needsDriftCheck(tc.m, now); got != tc.want {
+				t.Errorf("needsDriftCheck = %v, 기대값 %v", got, tc.want)
+			}
+		})
+	}
+}
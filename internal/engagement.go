@@ -0,0 +1,77 @@
+package internal
+
+import "sort"
+
+// 감성별로 보여줄 공감 많은 댓글 수
+const topEngagedPerLabel = 3
+
+// WeightedDistribution: 좋아요/답글 수로 가중한 감성 분포 (댓글 가중치 = 1 + 좋아요 + 답글)
+type WeightedDistribution struct {
+	PosWeight   int `json:"posWeight"`
+	NegWeight   int `json:"negWeight"`
+	NeuWeight   int `json:"neuWeight"`
+	TotalWeight int `json:"totalWeight"`
+	PosPercent  int `json:"posPercent"`
+	NegPercent  int `json:"negPercent"`
+	NeuPercent  int `json:"neuPercent"`
+}
+
+// EngagedComment: 공감(좋아요+답글)이 많은 댓글 (Ref는 1부터 시작하는 댓글 번호)
+type EngagedComment struct {
+	Ref        int    `json:"ref"`
+	Author     string `json:"author"`
+	Text       string `json:"text"`
+	LikeCount  int    `json:"likeCount"`
+	ReplyCount int    `json:"replyCount"`
+}
+
+// WeightedSentiment: 좋아요/답글 수를 반영한 감성 분포 계산
+func WeightedSentiment(comments []Comment, labels []string) WeightedDistribution {
+	var d WeightedDistribution
+	for i, c := range comments {
+		if i >= len(labels) {
+			break
+		}
+		w := 1 + c.Engagement()
+		switch labels[i] {
+		case "긍정":
+			d.PosWeight += w
+		case "부정":
+			d.NegWeight += w
+		case "중립":
+			d.NeuWeight += w
+		default:
+			continue
+		}
+		d.TotalWeight += w
+	}
+	percents := LargestRemainderPercents([]int{d.PosWeight, d.NegWeight, d.NeuWeight})
+	d.PosPercent, d.NegPercent, d.NeuPercent = percents[0], percents[1], percents[2]
+	return d
+}
+
+// TopEngagedByLabel: 감성 라벨별 공감 많은 댓글 상위 n개
+func TopEngagedByLabel(comments []Comment, labels []string, n int) map[string][]EngagedComment {
+	out := map[string][]EngagedComment{}
+	for _, label := range sentimentLabels {
+		idxs := make([]int, 0)
+		for i, l := range labels {
+			if l == label && i < len(comments) {
+				idxs = append(idxs, i)
+			}
+		}
+		sort.SliceStable(idxs, func(a, b int) bool {
+			return comments[idxs[a]].Engagement() > comments[idxs[b]].Engagement()
+		})
+		if len(idxs) > n {
+			idxs = idxs[:n]
+		}
+		top := make([]EngagedComment, 0, len(idxs))
+		for _, i := range idxs {
+			c := comments[i]
+			top = append(top, EngagedComment{Ref: i + 1, Author: c.Author, Text: c.Text, LikeCount: c.LikeCount, ReplyCount: c.ReplyCount})
+		}
+		out[label] = top
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWeightedSentiment(t *testing.T) {
+	cases := []struct {
+		name     string
+		comments []Comment
+		labels   []string
+		want     WeightedDistribution
+	}{
+		{"댓글 없음", nil, nil, WeightedDistribution{}},
+		{"공감 없으면 댓글 수와 같음", []Comment{{}, {}, {}, {}}, []string{"긍정", "긍정", "부정", "중립"},
+			WeightedDistribution{PosWeight: 2, NegWeight: 1, NeuWeight: 1, TotalWeight: 4, PosPercent: 50, NegPercent: 25, NeuPercent: 25}},
+		{"좋아요와 답글로 가중", []Comment{{LikeCount: 8, ReplyCount: 1}, {}}, []string{"부정", "긍정"},
+			WeightedDistribution{PosWeight: 1, NegWeight: 10, TotalWeight: 11, PosPercent: 9, NegPercent: 91}},
+		{"알 수 없는 라벨과 라벨 없는 댓글 제외", []Comment{{LikeCount: 5}, {}, {LikeCount: 9}}, []string{"분석불가", "긍정"},
+			WeightedDistribution{PosWeight: 1, TotalWeight: 1, PosPercent: 100}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := WeightedSentiment(tc.comments, tc.labels); got != tc.want {
+				t.Errorf("WeightedSentiment = %+v\n기대값 %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTopEngagedByLabel(t *testing.T) {
+	comments := []Comment{
+		{Author: "a", Text: "좋다", LikeCount: 1},
+		{Author: "b", Text: "최고", LikeCount: 10, ReplyCount: 2},
+		{Author: "c", Text: "별로", LikeCount: 3},
+		{Author: "d", Text: "굿", LikeCount: 1},
+		{Author: "e", Text: "짱", LikeCount: 5},
+	}
+	labels := []string{"긍정", "긍정", "부정", "긍정", "긍정"}
+	cases := []struct {
+		name string
+		n    int
+		want map[string][]EngagedComment
+	}{
+		{"상위 2개", 2, map[string][]EngagedComment{
+			"긍정": {{Ref: 2, Author: "b", Text: "최고", LikeCount: 10, ReplyCount: 2}, {Ref: 5, Author: "e", Text: "짱", LikeCount: 5}},
+			"부정": {{Ref: 3, Author: "c", Text: "별로", LikeCount: 3}},
+			"중립": {},
+		}},
+		{"동점 순서 유지", 4, map[string][]EngagedComment{
+			"긍정": {
+				{Ref: 2, Author: "b", Text: "최고", LikeCount: 10, ReplyCount: 2},
+				{Ref: 5, Author: "e", Text: "짱", LikeCount: 5},
+				{Ref: 1, Author: "a", Text: "좋다", LikeCount: 1},
+				{Ref: 4, Author: "d", Text: "굿", LikeCount: 1},
+			},
+			"부정": {{Ref: 3, Author: "c", Text: "별로", LikeCount: 3}},
+			"중립": {},
+		}},
+		{"0개", 0, map[string][]EngagedComment{"긍정": {}, "부정": {}, "중립": {}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := TopEngagedByLabel(comments, labels, tc.n); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("TopEngagedByLabel = %+v\n기대값 %+v", got, tc.want)
+			}
+		})
+	}
+}
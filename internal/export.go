@@ -0,0 +1,295 @@
+package internal
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 내보내기 형식과 표 이름
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+	ExportXLSX = "xlsx"
+
+	TableComments = "comments"
+	TableSummary  = "summary"
+	TableKeywords = "keywords"
+)
+
+// ExportRow: 댓글별 내보내기 행
+type ExportRow struct {
+	Ref         int       `json:"ref"`
+	Author      string    `json:"author"`
+	Text        string    `json:"text"`
+	Sentiment   string    `json:"sentiment"`
+	Confidence  float64   `json:"confidence"`
+	LikeCount   int       `json:"likeCount"`
+	ReplyCount  int       `json:"replyCount"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Language    string    `json:"language"`
+}
+
+// ExportKeyword: 단어 빈도 내보내기 행
+type ExportKeyword struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// exportTable: CSV/XLSX 공통 표 (numeric[i]이면 i번째 열은 숫자)
+type exportTable struct {
+	name    string
+	header  []string
+	numeric []bool
+	rows    [][]string
+}
+
+// ExportRows: 분석 결과를 댓글별 행으로 변환
+func ExportRows(res *AnalysisResult) []ExportRow {
+	rows := make([]ExportRow, 0, len(res.Comments))
+	for i, c := range res.Comments {
+		row := ExportRow{
+			Ref:         i + 1,
+			Author:      c.Author,
+			Text:        c.Text,
+			LikeCount:   c.LikeCount,
+			ReplyCount:  c.ReplyCount,
+			PublishedAt: c.PublishedAt,
+			Language:    c.Language,
+		}
+		if i < len(res.Sentiments) {
+			row.Sentiment = res.Sentiments[i].Label
+			row.Confidence = res.Sentiments[i].Confidence
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ExportKeywords: 단어 빈도를 많은 순으로 정렬 (같으면 가나다순)
+func ExportKeywords(res *AnalysisResult) []ExportKeyword {
+	words := make([]ExportKeyword, 0, len(res.WordFreq))
+	for w, n := range res.WordFreq {
+		words = append(words, ExportKeyword{Word: w, Count: n})
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
+	})
+	return words
+}
+
+func commentsTable(res *AnalysisResult) exportTable {
+	t := exportTable{
+		name:    "댓글",
+		header:  []string{"번호", "작성자", "댓글", "감성", "신뢰도", "좋아요", "답글", "작성시각", "언어"},
+		numeric: []bool{true, false, false, false, true, true, true, false, false},
+	}
+	for _, r := range ExportRows(res) {
+		published := ""
+		if !r.PublishedAt.IsZero() {
+			published = r.PublishedAt.Format(time.RFC3339)
+		}
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(r.Ref), r.Author, r.Text, r.Sentiment,
+			strconv.FormatFloat(r.Confidence, 'f', 2, 64),
+			strconv.Itoa(r.LikeCount), strconv.Itoa(r.ReplyCount), published, r.Language,
+		})
+	}
+	return t
+}
+
+func summaryTable(res *AnalysisResult) exportTable {
+	t := exportTable{name: "요약", header: []string{"항목", "값"}, numeric: []bool{false, false}}
+	add := func(k, v string) { t.rows = append(t.rows, []string{k, v}) }
+	add("영상 ID", res.VideoID)
+	add("제목", res.Meta.Title)
+	add("채널", res.Meta.Channel)
+	add("분석 댓글 수", strconv.Itoa(res.TotalCount))
+	add("수집 댓글 수", strconv.Itoa(res.Sampling.FetchedCount))
+	add("전체 댓글 수", strconv.Itoa(res.Sampling.AvailableCount))
+	add("표본 비율", fmt.Sprintf("%.1f%%", res.Sampling.SamplingRate*100))
+	for _, s := range res.Shares {
+		add(s.Label, fmt.Sprintf("%d개 (%d%%, 95%% 신뢰구간 %.1f~%.1f%%)", s.Count, s.Percent, s.Lower, s.Upper))
+	}
+	add("스팸 댓글", strconv.Itoa(res.Moderation.SpamCount))
+	add("유해 댓글", strconv.Itoa(res.Moderation.ToxicCount))
+	add("키워드 점수 방식", res.KeywordScorer)
+	add("상위 키워드", strings.Join(res.TopKeywords, ", "))
+	add("전체 분위기", res.Insight.OverallMood)
+	return t
+}
+
+func keywordsTable(res *AnalysisResult) exportTable {
+	t := exportTable{name: "키워드", header: []string{"단어", "빈도"}, numeric: []bool{false, true}}
+	for _, k := range ExportKeywords(res) {
+		t.rows = append(t.rows, []string{k.Word, strconv.Itoa(k.Count)})
+	}
+	return t
+}
+
+// WriteCSV: 표 하나(comments, summary, keywords)를 엑셀에서 한글이 깨지지 않도록 UTF-8 BOM을 붙인 CSV로 출력
+func WriteCSV(w io.Writer, res *AnalysisResult, table string) error {
+	var t exportTable
+	switch table {
+	case "", TableComments:
+		t = commentsTable(res)
+	case TableSummary:
+		t = summaryTable(res)
+	case TableKeywords:
+		t = keywordsTable(res)
+	default:
+		return fmt.Errorf("알 수 없는 표: %s", table)
+	}
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.header); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		out := make([]string, len(row))
+		for i, v := range row {
+			out[i] = v
+			if !t.numeric[i] {
+				out[i] = escapeCSVFormula(v)
+			}
+		}
+		if err := cw.Write(out); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeCSVFormula: 엑셀이 수식으로 실행하지 않도록 =, +, -, @로 시작하는 값 앞에 ' 추가
+func escapeCSVFormula(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// WriteExportJSON: 요약, 댓글별 행, 단어 빈도를 JSON으로 출력
+func WriteExportJSON(w io.Writer, res *AnalysisResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"videoId":     res.VideoID,
+		"meta":        res.Meta,
+		"totalCount":  res.TotalCount,
+		"shares":      res.Shares,
+		"sampling":    res.Sampling,
+		"moderation":  map[string]int{"spamCount": res.Moderation.SpamCount, "toxicCount": res.Moderation.ToxicCount},
+		"topKeywords": res.TopKeywords,
+		"insight":     res.Insight,
+		"comments":    ExportRows(res),
+		"keywords":    ExportKeywords(res),
+	})
+}
+
+// WriteXLSX: 댓글, 요약, 키워드 시트를 가진 XLSX(Office Open XML) 파일 출력
+func WriteXLSX(w io.Writer, res *AnalysisResult) error {
+	tables := []exportTable{commentsTable(res), summaryTable(res), keywordsTable(res)}
+	zw := zip.NewWriter(w)
+	var sheets, rels, overrides strings.Builder
+	for i, t := range tables {
+		n := i + 1
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(t.name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+	}
+	stylesRel := len(tables) + 1
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, stylesRel)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+		{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`},
+	}
+	for i, t := range tables {
+		parts = append(parts, struct{ name, body string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(t)})
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// worksheetXML: 표를 시트 XML로 변환 (머리글은 굵게, 숫자 열은 숫자 셀, 나머지는 인라인 문자열)
+func worksheetXML(t exportTable) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeRow := func(r int, cells []string, header bool) {
+		fmt.Fprintf(&sb, `<row r="%d">`, r)
+		for i, v := range cells {
+			ref := xlsxColumn(i) + strconv.Itoa(r)
+			switch {
+			case header:
+				fmt.Fprintf(&sb, `<c r="%s" t="inlineStr" s="1"><is><t>%s</t></is></c>`, ref, xmlEscape(v))
+			case t.numeric[i] && v != "":
+				fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, xmlEscape(v))
+			default:
+				fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(v))
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+	writeRow(1, t.header, true)
+	for i, row := range t.rows {
+		writeRow(i+2, row, false)
+	}
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+// xlsxColumn: 0부터 시작하는 열 번호를 A, B, ..., Z, AA 형식으로 변환
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xmlEscape: XML 특수문자 이스케이프 (XML에 쓸 수 없는 제어문자는 U+FFFD로 대체)
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
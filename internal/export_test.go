@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func exportFixture() *AnalysisResult {
+	return &AnalysisResult{
+		VideoID:    "dQw4w9WgXcQ",
+		Comments:   []Comment{{Author: "팬1", Text: "노래 좋아요, 최고", LikeCount: 3}, {Author: "=cmd", Text: "-별로 <b>"}},
+		Sentiments: []SentimentResult{{Label: "긍정", Confidence: 0.9}, {Label: "부정", Confidence: 0.75}},
+		WordFreq:   map[string]int{"노래": 2, "최고": 1},
+		TotalCount: 2,
+		Shares:     SentimentShares(1, 1, 0, 0),
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, exportFixture(), TableComments); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("\xEF\xBB\xBF")) {
+		t.Fatal("CSV에 UTF-8 BOM이 없음")
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\uFEFF"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("CSV %d행, 기대값 머리행 + 2행", len(records))
+	}
+	if got := records[1]; got[2] != "노래 좋아요, 최고" || got[3] != "긍정" || got[4] != "0.90" || got[5] != "3" {
+		t.Errorf("첫 번째 댓글 행 = %v", got)
+	}
+	if got := records[2]; got[1] != "'=cmd" || got[2] != "'-별로 <b>" {
+		t.Errorf("수식처럼 보이는 셀이 이스케이프되지 않음: %v", got)
+	}
+	if err := WriteCSV(&buf, exportFixture(), "unknown"); err == nil {
+		t.Error("알 수 없는 표인데 에러가 없음")
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, exportFixture()); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{
+		"[Content_Types].xml": false, "_rels/.rels": false, "xl/workbook.xml": false, "xl/_rels/workbook.xml.rels": false,
+		"xl/styles.xml": false, "xl/worksheets/sheet1.xml": false, "xl/worksheets/sheet2.xml": false, "xl/worksheets/sheet3.xml": false,
+	}
+	for _, f := range zr.File {
+		want[f.Name] = true
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		// 모든 파트가 올바른 XML인지 확인
+		dec := xml.NewDecoder(bytes.NewReader(body))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+		}
+		if f.Name == "xl/worksheets/sheet1.xml" && !strings.Contains(string(body), "-별로 &lt;b&gt;") {
+			t.Errorf("sheet1에 이스케이프된 댓글 본문이 없음: %s", body)
+		}
+	}
+	for name, ok := range want {
+		if !ok {
+			t.Errorf("XLSX에 %s 파트가 없음", name)
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, 기대값 %s", i, got, want)
+		}
+	}
+}
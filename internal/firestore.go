@@ -2,6 +2,8 @@ package internal
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"os"
 
@@ -61,6 +63,20 @@ type Meeting struct {
 	CreatedAt       int64  `firestore:"createdAt"`
 	MaxParticipants int    `firestore:"maxParticipants"`
 	Status          string `firestore:"status"`
+	VideoID         string `firestore:"videoId"`
+	AnalysisID      string `firestore:"analysisId"`      // 모임 생성 근거가 된 분석 기록 (analyses 컬렉션)
+	DriftAlert      bool   `firestore:"driftAlert"`      // 부정 비율이 임계값을 넘었는지 (모임 생성자에게 표시)
+	NegativePercent int    `firestore:"negativePercent"` // 최근 재분석의 부정 비율(%)
+	DriftCheckedAt  int64  `firestore:"driftCheckedAt"`
+}
+
+// 문서 ID 생성 (랜덤 16자리 hex, 모임과 분석 기록에 사용)
+func newRecordID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
 
 // 모임 생성
@@ -96,8 +112,16 @@ func GetMeetingByID(ctx context.Context, meetingID string) (Meeting, error) {
 }
 
 type Participant struct {
-	Name  string `firestore:"name"`
-	Email string `firestore:"email"`
+	MeetingID string `firestore:"meetingId"`
+	Name      string `firestore:"name"`
+	Email     string `firestore:"email"`
+	CreatedAt int64  `firestore:"createdAt"`
+}
+
+// 참가자 신청 저장
+func SaveParticipant(ctx context.Context, p Participant) error {
+	_, _, err := firestoreClient.Collection("participants").Add(ctx, p)
+	return err
 }
 
 // 참가자 목록 조회
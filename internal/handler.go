@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
-	"math/rand"
+	"log"
 	"net/http"
 	"os"
 	"strconv"
-	"sync"
+	"strings"
 	"time"
 )
 
@@ -26,126 +26,553 @@ func AnalyzeHandler(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
 	}
+	if playlistID := playlistRouteID(r.FormValue("video_id")); playlistID != "" {
+		playlistHandler(w, r, playlistID)
+		return
+	}
 
-	var videoID string
-	if r.FormValue("random") == "1" {
-		id, err := FetchRandomPopularVideoID()
+	videoID, status, err := videoIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	opt := analysisOptionsFromRequest(r)
+	res, err := CachedAnalysis(r.Context(), videoID, opt)
+	if err != nil {
+		http.Error(w, "유튜브 댓글 수집 실패", 500)
+		return
+	}
+	// 분석 기록 저장 (실패해도 결과는 보여줌)
+	recordID, _ := SaveAnalysis(r.Context(), currentUserID(r), opt, res)
+	renderAnalysisPage(w, res, recordID)
+}
+
+// renderAnalysisPage: 분석 결과 페이지 렌더링.
+// 저장된 기록이 있으면 차트를 /analysis/chart 엔드포인트로, 없으면 정적 파일로 생성
+func renderAnalysisPage(w http.ResponseWriter, res *AnalysisResult, recordID string) {
+	chartPath := func(kind string) string {
+		return "/analysis/chart?id=" + recordID + "&type=" + kind
+	}
+	wordcloudPath, piechartPath := chartPath(ChartWordCloud), chartPath(ChartPie)
+	// 내보내기 링크 (format=csv|json|xlsx를 붙여 사용, 기록이 없으면 비어 있음)
+	exportPath, reportPath := "", ""
+	if recordID != "" {
+		exportPath = "/analysis/export?id=" + recordID
+		reportPath = "/report?id=" + recordID
+	}
+	emotionChart, timelineChart := "", ""
+	if res.Emotions != nil {
+		emotionChart = chartPath(ChartEmotion)
+	}
+	if len(res.Timeline.Buckets) > 0 {
+		timelineChart = chartPath(ChartTimeline)
+	}
+	if recordID == "" {
+		// 워드클라우드/파이차트 생성
+		_ = GenerateWordCloud(res.WordFreq, "web/static/wordcloud.html")
+		_ = GeneratePieChart(res.Labels, "web/static/piechart.html")
+		wordcloudPath, piechartPath = "/static/wordcloud.html", "/static/piechart.html"
+		// 감정 분포 차트 생성 (감정 분석 옵션 사용시)
+		emotionChart = ""
+		if res.Emotions != nil && GenerateEmotionChart(res.EmotionLabels(), "web/static/emotionchart.html") == nil {
+			emotionChart = "/static/emotionchart.html"
+		}
+		// 업로드 후 감성 변화 타임라인 차트 생성
+		timelineChart = ""
+		if len(res.Timeline.Buckets) > 0 && GenerateTimelineChart(res.Timeline, "web/static/timeline.html") == nil {
+			timelineChart = "/static/timeline.html"
+		}
+	}
+	// 결과 템플릿 렌더링
+	tmpl, err := template.ParseFiles("web/templates/result.html")
+	if err != nil {
+		http.Error(w, "템플릿 에러", 500)
+		return
+	}
+	tmpl.Execute(w, map[string]interface{}{
+		"Comments":      res.Comments,
+		"Sentiments":    res.Labels,
+		"WordCloudPath": wordcloudPath,
+		"PieChartPath":  piechartPath,
+		"AnalysisID":    recordID,
+		"ExportPath":    exportPath,
+		"ReportPath":    reportPath,
+		"Insight":       res.Insight,
+		"TopKeywords":   res.TopKeywords,
+		"KeywordScorer": res.KeywordScorer,
+		"Aspects":       res.Aspects,
+		"Emotions":      res.Emotions,
+		"EmotionChart":  emotionChart,
+		"SarcasmCount":  res.SarcasmCount,
+		"EligibleCount": len(res.EligibleAuthors()),
+		"Moderation":    res.Moderation,
+		"SpamCount":     res.Moderation.SpamCount,
+		"ToxicCount":    res.Moderation.ToxicCount,
+		"EmojiStats":    res.EmojiStats,
+		"SlangStats":    res.SlangStats,
+		"Languages":     res.Languages,
+		"TimelineChart": timelineChart,
+		"VideoTitle":    res.Meta.Title,
+		"VideoChannel":  res.Meta.Channel,
+		"VideoThumb":    res.Meta.Thumbnail,
+		"PosCount":      res.PosCount,
+		"NegCount":      res.NegCount,
+		"NeuCount":      res.NeuCount,
+		"PosPercent":    res.Share("긍정").Percent,
+		"NegPercent":    res.Share("부정").Percent,
+		"NeuPercent":    res.Share("중립").Percent,
+		"Shares":        res.Shares,
+		"Sampling":      res.Sampling,
+		"TotalCount":    res.TotalCount,
+		"Weighted":      res.Weighted,
+		"TopEngaged":    res.TopEngaged,
+		"Topics":        res.Topics,
+		"FallbackCount": res.FallbackCount,
+	})
+}
+
+// 재생목록 분석 페이지 (videos=분석할 영상 수)
+func playlistHandler(w http.ResponseWriter, r *http.Request, playlistID string) {
+	opt := analysisOptionsFromRequest(r)
+	pa, err := AnalyzePlaylist(r.Context(), playlistID, atoi(r.FormValue("videos")), opt)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	savePlaylistVideos(r, pa, opt)
+	sentimentChart := ""
+	if GenerateVideoSentimentChart(pa.MultiVideoAnalysis, "web/static/playlistchart.html") == nil {
+		sentimentChart = "/static/playlistchart.html"
+	}
+	_ = GenerateWordCloud(pa.WordFreq, "web/static/wordcloud.html")
+	tmpl, err := template.ParseFiles("web/templates/playlist.html")
+	if err != nil {
+		http.Error(w, "템플릿 에러", 500)
+		return
+	}
+	tmpl.Execute(w, map[string]interface{}{
+		"Playlist":       pa.Playlist,
+		"Videos":         pa.Videos,
+		"Shares":         pa.Shares,
+		"PosCount":       pa.PosCount,
+		"NegCount":       pa.NegCount,
+		"NeuCount":       pa.NeuCount,
+		"TotalCount":     pa.TotalCount,
+		"TopKeywords":    pa.TopKeywords,
+		"KeywordTrends":  pa.KeywordTrends,
+		"FailedVideoIDs": pa.FailedVideoIDs,
+		"SentimentChart": sentimentChart,
+		"WordCloudPath":  "/static/wordcloud.html",
+	})
+}
+
+// savePlaylistVideos: 집계에 쓴 영상별 결과를 기록으로 저장하고 상세 링크(/analysis?id=)용 기록 ID 연결
+// (다시 분석하지 않고 집계 수치와 같은 결과를 저장, 분석은 POST에서만 실행)
+func savePlaylistVideos(r *http.Request, pa *PlaylistAnalysis, opt AnalysisOptions) {
+	recordIDs := map[string]string{}
+	for _, res := range pa.Results {
+		if id, err := SaveAnalysis(r.Context(), currentUserID(r), opt, res); err == nil {
+			recordIDs[res.VideoID] = id
+		}
+	}
+	for i, v := range pa.Videos {
+		pa.Videos[i].AnalysisID = recordIDs[v.VideoID]
+	}
+}
+
+// 분석 결과 JSON API (재생목록 URL이면 재생목록 집계 결과)
+func AnalyzeAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if playlistID := playlistRouteID(r.FormValue("video_id")); playlistID != "" {
+		opt := analysisOptionsFromRequest(r)
+		pa, err := AnalyzePlaylist(r.Context(), playlistID, atoi(r.FormValue("videos")), opt)
 		if err != nil {
-			http.Error(w, "인기 영상 조회 실패", 500)
+			writeJSONError(w, 500, err.Error())
 			return
 		}
-		videoID = id
+		savePlaylistVideos(r, pa, opt)
+		writeJSON(w, http.StatusOK, pa)
+		return
+	}
+	videoID, status, err := videoIDFromRequest(r)
+	if err != nil {
+		writeJSONError(w, status, err.Error())
+		return
+	}
+	opt := analysisOptionsFromRequest(r)
+	res, err := CachedAnalysis(r.Context(), videoID, opt)
+	if err != nil {
+		writeJSONError(w, 500, "유튜브 댓글 수집 실패")
+		return
+	}
+	recordID, _ := SaveAnalysis(r.Context(), currentUserID(r), opt, res)
+	writeJSON(w, http.StatusOK, struct {
+		AnalysisID string `json:"analysisId,omitempty"`
+		*AnalysisResult
+	}{recordID, res})
+}
+
+// 저장된 분석 결과 페이지 (/analysis?id=)
+func AnalysisPermalinkHandler(w http.ResponseWriter, r *http.Request) {
+	rec, err := GetAnalysis(r.Context(), r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "분석 기록을 찾을 수 없음", 404)
+		return
+	}
+	renderAnalysisPage(w, rec.Result, rec.ID)
+}
+
+// 저장된 분석 결과의 차트 (/analysis/chart?id=&type=wordcloud|pie|emotion|timeline, format=svg면 SVG 이미지)
+func AnalysisChartHandler(w http.ResponseWriter, r *http.Request) {
+	rec, err := GetAnalysis(r.Context(), r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "분석 기록을 찾을 수 없음", 404)
+		return
+	}
+	var buf bytes.Buffer
+	contentType := "text/html; charset=utf-8"
+	if r.FormValue("format") == "svg" {
+		err = RenderResultSVG(&buf, rec.Result, r.FormValue("type"))
+		contentType = "image/svg+xml"
 	} else {
-		input := r.FormValue("video_id")
-		videoID = ParseVideoID(input)
-		if videoID == "" {
-			http.Error(w, "유효한 YouTube 영상 ID 또는 URL을 입력하세요.", 400)
-			return
+		err = RenderResultChart(&buf, rec.Result, r.FormValue("type"))
+	}
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+}
+
+// 분석 결과 내보내기 (/analysis/export?id=&format=csv|json|xlsx, CSV는 table=comments|summary|keywords)
+func ExportHandler(w http.ResponseWriter, r *http.Request) {
+	rec, err := GetAnalysis(r.Context(), r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "분석 기록을 찾을 수 없음", 404)
+		return
+	}
+	filename := "analysis_" + rec.VideoID
+	var buf bytes.Buffer
+	switch format := r.FormValue("format"); format {
+	case "", ExportCSV:
+		table := r.FormValue("table")
+		if table == "" {
+			table = TableComments
 		}
+		err = WriteCSV(&buf, rec.Result, table)
+		filename += "_" + table + ".csv"
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	case ExportJSON:
+		err = WriteExportJSON(&buf, rec.Result)
+		filename += ".json"
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	case ExportXLSX:
+		err = WriteXLSX(&buf, rec.Result)
+		filename += ".xlsx"
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	default:
+		http.Error(w, "지원하지 않는 형식: "+format, 400)
+		return
 	}
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Write(buf.Bytes())
+}
 
-	meta, _ := FetchVideoMeta(videoID)
+// 공유용 단일 HTML 리포트 (/report?id=&mode=interactive|static, download=1이면 파일로 받기).
+// 계정 없는 사람과 공유하므로 로그인 없이 열리며, 추측하기 어려운 기록 ID가 공유 링크 역할
+func ReportHandler(w http.ResponseWriter, r *http.Request) {
+	rec, err := GetAnalysis(r.Context(), r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "분석 기록을 찾을 수 없음", 404)
+		return
+	}
+	mode := r.FormValue("mode")
+	if mode != "" && mode != ReportInteractive && mode != ReportStatic {
+		http.Error(w, "지원하지 않는 리포트 모드: "+mode, 400)
+		return
+	}
+	var buf bytes.Buffer
+	if err := GenerateReport(&buf, rec.Result, mode); err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if r.FormValue("download") == "1" {
+		w.Header().Set("Content-Disposition", `attachment; filename="report_`+rec.VideoID+`.html"`)
+	}
+	w.Write(buf.Bytes())
+}
 
-	comments, err := FetchComments(videoID)
+// 분석 기록 JSON API (/api/analysis?id=)
+func AnalysisRecordAPIHandler(w http.ResponseWriter, r *http.Request) {
+	rec, err := GetAnalysis(r.Context(), r.FormValue("id"))
 	if err != nil {
-		http.Error(w, "유튜브 댓글 수집 실패", 500)
+		writeJSONError(w, 404, "분석 기록을 찾을 수 없음")
 		return
 	}
-	// 1. 댓글 100개로 제한 (랜덤 샘플링)
-	maxComments := 100
-	if len(comments) > maxComments {
-		rand.Seed(time.Now().UnixNano())
-		perm := rand.Perm(len(comments))[:maxComments]
-		sampled := make([]Comment, 0, maxComments)
-		for _, idx := range perm {
-			sampled = append(sampled, comments[idx])
-		}
-		comments = sampled
-	}
-	// 댓글 텍스트 배열
-	commentTexts := make([]string, 0, len(comments))
-	for _, c := range comments {
-		commentTexts = append(commentTexts, c.Text)
-	}
-	// 2. 감성분석 병렬 처리 (최대 5개 동시)
-	type resultItem struct {
-		idx   int
-		label string
-	}
-	results := make([]string, len(comments))
-	ch := make(chan resultItem, len(comments))
-	sem := make(chan struct{}, 5) // 동시 5개 제한
-	var wg sync.WaitGroup
-	for i, c := range comments {
-		wg.Add(1)
-		go func(idx int, text string) {
-			defer wg.Done()
-			sem <- struct{}{}
-			label, _ := AnalyzeSentiment(text)
-			ch <- resultItem{idx, label}
-			<-sem
-		}(i, c.Text)
-	}
-	wg.Wait()
-	close(ch)
-	for r := range ch {
-		results[r.idx] = r.label
-	}
-	// 워드클라우드/차트 파일 경로
-	wordcloudPath := "web/static/wordcloud.html"
-	piechartPath := "web/static/piechart.html"
-	// 워드클라우드 생성
-	freq := CountWords(commentTexts)
-	_ = GenerateWordCloud(freq, wordcloudPath)
-	// 파이차트 생성
-	_ = GeneratePieChart(results, piechartPath)
-	// 상위 키워드 추출 및 인사이트 요약
-	topKeywords := TopNWords(freq, 5)
-	insight, _ := SummarizeKeywords(topKeywords)
-	// 감성분석 결과 개수/비율 계산
-	pos, neg, neu := 0, 0, 0
-	total := len(results)
-	for _, label := range results {
-		switch label {
-		case "긍정":
-			pos++
-		case "부정":
-			neg++
-		case "중립":
-			neu++
-		}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// 분석 기록 목록 페이지 (내 기록, video_id가 있으면 그 영상의 내 기록)
+func HistoryHandler(w http.ResponseWriter, r *http.Request) {
+	entries, videoID, err := historyFromRequest(r)
+	if err != nil {
+		http.Error(w, "분석 기록 조회 실패", 500)
+		return
+	}
+	tmpl, err := template.ParseFiles("web/templates/history.html")
+	if err != nil {
+		http.Error(w, "템플릿 에러", 500)
+		return
+	}
+	tmpl.Execute(w, map[string]interface{}{
+		"Entries": entries,
+		"VideoID": videoID,
+	})
+}
+
+// 분석 기록 목록 JSON API
+func HistoryAPIHandler(w http.ResponseWriter, r *http.Request) {
+	entries, _, err := historyFromRequest(r)
+	if err != nil {
+		writeJSONError(w, 500, "분석 기록 조회 실패")
+		return
 	}
-	percent := func(n int) int {
-		if total == 0 {
-			return 0
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// historyFromRequest: 로그인 사용자의 분석 기록 조회 (video_id가 있으면 그 영상의 본인 기록만)
+func historyFromRequest(r *http.Request) ([]HistoryEntry, string, error) {
+	videoID := ""
+	if input := r.FormValue("video_id"); input != "" {
+		if videoID = ParseVideoID(input); videoID == "" {
+			return []HistoryEntry{}, "", nil
 		}
-		return n * 100 / total
 	}
-	// 결과 템플릿 렌더링
-	tmpl, err := template.ParseFiles("web/templates/result.html")
+	entries, err := ListAnalyses(r.Context(), currentUserID(r), videoID)
+	return entries, videoID, err
+}
+
+// 여러 영상 비교 페이지
+func CompareHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	videoIDs, err := videoIDsFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	cmp, err := CompareVideos(r.Context(), videoIDs, analysisOptionsFromRequest(r))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	compareChart := ""
+	if GenerateCompareChart(cmp.Videos, "web/static/comparechart.html") == nil {
+		compareChart = "/static/comparechart.html"
+	}
+	tmpl, err := template.ParseFiles("web/templates/compare.html")
 	if err != nil {
 		http.Error(w, "템플릿 에러", 500)
 		return
 	}
 	tmpl.Execute(w, map[string]interface{}{
-		"Comments":      comments,
-		"Sentiments":    results,
-		"WordCloudPath": "/static/wordcloud.html",
-		"PieChartPath":  "/static/piechart.html",
-		"Insight":       insight,
-		"VideoTitle":    meta.Title,
-		"VideoChannel":  meta.Channel,
-		"VideoThumb":    meta.Thumbnail,
-		"PosCount":      pos,
-		"NegCount":      neg,
-		"NeuCount":      neu,
-		"PosPercent":    percent(pos),
-		"NegPercent":    percent(neg),
-		"NeuPercent":    percent(neu),
-		"TotalCount":    total,
+		"Videos":         cmp.Videos,
+		"CompareChart":   compareChart,
+		"SharedKeywords": cmp.SharedKeywords,
+		"Distinctive":    cmp.Distinctive,
+		"SharedTopics":   cmp.SharedTopics,
+		"InsightDiffs":   cmp.InsightDiffs,
 	})
 }
 
+// 여러 영상 비교 JSON API
+func CompareAPIHandler(w http.ResponseWriter, r *http.Request) {
+	videoIDs, err := videoIDsFromRequest(r)
+	if err != nil {
+		writeJSONError(w, 400, err.Error())
+		return
+	}
+	cmp, err := CompareVideos(r.Context(), videoIDs, analysisOptionsFromRequest(r))
+	if err != nil {
+		writeJSONError(w, 500, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, cmp)
+}
+
+// 채널 최근 영상 분석 페이지 (channel=채널 URL 또는 @핸들, videos=분석할 영상 수)
+func ChannelHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	ca, err := AnalyzeChannel(r.Context(), r.FormValue("channel"), atoi(r.FormValue("videos")), analysisOptionsFromRequest(r))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	sentimentChart := ""
+	if GenerateVideoSentimentChart(ca.MultiVideoAnalysis, "web/static/channelchart.html") == nil {
+		sentimentChart = "/static/channelchart.html"
+	}
+	trendChart := ""
+	if len(ca.KeywordTrends) > 0 && GenerateKeywordTrendChart(ca.MultiVideoAnalysis, "web/static/keywordtrend.html") == nil {
+		trendChart = "/static/keywordtrend.html"
+	}
+	tmpl, err := template.ParseFiles("web/templates/channel.html")
+	if err != nil {
+		http.Error(w, "템플릿 에러", 500)
+		return
+	}
+	tmpl.Execute(w, map[string]interface{}{
+		"Channel":             ca.Channel,
+		"Videos":              ca.Videos,
+		"Shares":              ca.Shares,
+		"PosCount":            ca.PosCount,
+		"NegCount":            ca.NegCount,
+		"NeuCount":            ca.NeuCount,
+		"TotalCount":          ca.TotalCount,
+		"TopKeywords":         ca.TopKeywords,
+		"RecurringCommenters": ca.RecurringCommenters,
+		"KeywordTrends":       ca.KeywordTrends,
+		"FailedVideoIDs":      ca.FailedVideoIDs,
+		"SentimentChart":      sentimentChart,
+		"KeywordTrendChart":   trendChart,
+	})
+}
+
+// 채널 최근 영상 분석 JSON API
+func ChannelAPIHandler(w http.ResponseWriter, r *http.Request) {
+	ca, err := AnalyzeChannel(r.Context(), r.FormValue("channel"), atoi(r.FormValue("videos")), analysisOptionsFromRequest(r))
+	if err != nil {
+		writeJSONError(w, 500, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, ca)
+}
+
+// videoIDsFromRequest: video_id 여러 개 또는 video_ids(쉼표/줄바꿈 구분)에서 중복 없이 영상 ID 추출
+func videoIDsFromRequest(r *http.Request) ([]string, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	inputs := append([]string{}, r.Form["video_id"]...)
+	inputs = append(inputs, strings.FieldsFunc(r.FormValue("video_ids"), func(c rune) bool {
+		return c == ',' || c == '\n' || c == '\r' || c == ' '
+	})...)
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, in := range inputs {
+		if strings.TrimSpace(in) == "" {
+			continue
+		}
+		id := ParseVideoID(strings.TrimSpace(in))
+		if id == "" {
+			return nil, fmt.Errorf("유효하지 않은 YouTube 영상 ID 또는 URL: %s", in)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < minCompareVideos || len(ids) > maxCompareVideos {
+		return nil, fmt.Errorf("비교할 영상을 %d~%d개 입력하세요.", minCompareVideos, maxCompareVideos)
+	}
+	return ids, nil
+}
+
+// videoIDFromRequest: random=1이면 인기 영상, 아니면 video_id 입력값에서 영상 ID 추출
+func videoIDFromRequest(r *http.Request) (string, int, error) {
+	if r.FormValue("random") == "1" {
+		id, err := FetchRandomPopularVideoID()
+		if err != nil {
+			return "", 500, fmt.Errorf("인기 영상 조회 실패")
+		}
+		return id, 0, nil
+	}
+	videoID := ParseVideoID(r.FormValue("video_id"))
+	if videoID == "" {
+		return "", 400, fmt.Errorf("유효한 YouTube 영상 ID 또는 URL을 입력하세요.")
+	}
+	return videoID, 0, nil
+}
+
+// analysisOptionsFromRequest: 폼 값으로 분석 옵션 설정 (aspects=1 측면 분석, emotions=1 감정 분류, keep_flagged=1 스팸 포함,
+// stopwords=추가 불용어, keywords=freq|tfidf|llr 키워드 점수 방식, phrases=0 연어 추출 끔,
+// squash=1 반복 글자 줄이기, emoji_norm=1 이모지 통일,
+// offline=1 OpenAI 없이 사전 기반 감성분석, langs=ko,en 분석할 언어 제한,
+// timeline=hour|day 타임라인 구간 단위, topics=1 토픽 군집 (topics=N이면 토픽 N개))
+func analysisOptionsFromRequest(r *http.Request) AnalysisOptions {
+	opt := DefaultAnalysisOptions()
+	opt.UserID = currentUserID(r)
+	opt.Aspects = r.FormValue("aspects") == "1"
+	opt.Emotions = r.FormValue("emotions") == "1"
+	opt.KeepFlagged = r.FormValue("keep_flagged") == "1"
+	opt.Stopwords = ParseWordList(r.FormValue("stopwords"))
+	opt.Phrases = r.FormValue("phrases") != "0"
+	opt.SquashRepeats = r.FormValue("squash") == "1"
+	opt.NormalizeEmoji = r.FormValue("emoji_norm") == "1"
+	opt.Offline = r.FormValue("offline") == "1"
+	opt.Languages = ParseWordList(r.FormValue("langs"))
+	opt.TimelineUnit = r.FormValue("timeline")
+	if topics := r.FormValue("topics"); topics != "" && topics != "0" {
+		opt.Topics = true
+		if n := atoi(topics); n > 1 {
+			opt.TopicCount = n
+		}
+	}
+	if scorer := r.FormValue("keywords"); scorer != "" {
+		opt.KeywordScorer = scorer
+	}
+	return opt
+}
+
+// 채널별 불용어 조회(GET)/저장(POST) API (로그인한 사용자 본인의 목록만 다룸)
+func StopwordsAPIHandler(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	if userID == "" {
+		writeJSONError(w, 401, "로그인이 필요합니다.")
+		return
+	}
+	channelID := r.FormValue("channel_id")
+	if channelID == "" {
+		writeJSONError(w, 400, "channel_id가 필요합니다.")
+		return
+	}
+	if r.Method == http.MethodPost {
+		words := ParseWordList(r.FormValue("words"))
+		if err := SaveChannelStopwords(r.Context(), userID, channelID, words); err != nil {
+			writeJSONError(w, 500, "불용어 저장 실패: "+err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"channelId": channelID, "words": words})
+		return
+	}
+	words, err := GetChannelStopwords(r.Context(), userID, channelID)
+	if err != nil {
+		writeJSONError(w, 500, "불용어 조회 실패: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"channelId": channelID, "words": words})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
 // 회원가입 핸들러
 func SignupHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
@@ -290,32 +717,54 @@ func CreateMeetingHandler(w http.ResponseWriter, r *http.Request) {
 	description := r.FormValue("description")
 	meetingDate := r.FormValue("meetingDate")
 	maxParticipants := r.FormValue("maxParticipants")
-	if meetingName == "" {
-		comments, _ := FetchComments(ParseVideoID(youtubeUrl))
-		pos, neg, neu := 0, 0, 0
-		for _, c := range comments {
-			label, _ := AnalyzeSentiment(c.Text)
-			switch label {
-			case "긍정":
-				pos++
-			case "부정":
-				neg++
-			case "중립":
-				neu++
-			}
-		}
-		summary := "긍정: " + itoa(pos) + ", 부정: " + itoa(neg) + ", 중립: " + itoa(neu) + " (상위 20개 댓글 기준)"
-		tmpl, _ := template.ParseFiles("web/templates/create.html")
-		tmpl.Execute(w, map[string]interface{}{
-			"ShowForm":        true,
-			"AnalysisSummary": summary,
-			"YoutubeUrl":      youtubeUrl,
-		})
+	analysisID := r.FormValue("analysisId")
+	videoID := ParseVideoID(youtubeUrl)
+	if videoID == "" {
+		http.Error(w, "유효한 YouTube 영상 ID 또는 URL을 입력하세요.", 400)
 		return
 	}
+	// 폼으로 넘어온 분석 기록은 같은 영상을 본인이 분석한 것만 연결 (아니면 다시 분석)
+	if analysisID != "" {
+		if rec, err := GetAnalysis(r.Context(), analysisID); err != nil || !analysisMatches(rec, videoID, currentUserID(r)) {
+			analysisID = ""
+		}
+	}
+	if meetingName == "" || analysisID == "" {
+		res, err := CachedAnalysis(r.Context(), videoID, DefaultAnalysisOptions())
+		if err != nil {
+			http.Error(w, "유튜브 댓글 수집 실패", 500)
+			return
+		}
+		analysisID, err = SaveAnalysis(r.Context(), currentUserID(r), DefaultAnalysisOptions(), res)
+		if err != nil {
+			http.Error(w, "분석 기록 저장 실패: "+err.Error(), 500)
+			return
+		}
+		if meetingName == "" {
+			summary := fmt.Sprintf("긍정: %d, 부정: %d, 중립: %d (댓글 %d개 분석)", res.PosCount, res.NegCount, res.NeuCount, res.TotalCount)
+			tmpl, _ := template.ParseFiles("web/templates/create.html")
+			tmpl.Execute(w, map[string]interface{}{
+				"ShowForm":        true,
+				"AnalysisSummary": summary,
+				"AnalysisID":      analysisID,
+				"Insight":         res.Insight,
+				"YoutubeUrl":      youtubeUrl,
+			})
+			return
+		}
+	}
 	// Firestore에 모임 정보 저장
 	ctx := r.Context()
+	meetingID, err := newRecordID()
+	if err != nil {
+		http.Error(w, "모임 ID 생성 실패: "+err.Error(), 500)
+		return
+	}
 	m := Meeting{
+		MeetingID:       meetingID,
+		CreatorID:       currentUserID(r),
+		VideoID:         videoID,
+		AnalysisID:      analysisID,
 		YoutubeUrl:      youtubeUrl,
 		MeetingName:     meetingName,
 		Description:     description,
@@ -324,8 +773,7 @@ func CreateMeetingHandler(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:       time.Now().Unix(),
 		Status:          "active",
 	}
-	err := CreateMeeting(ctx, m)
-	if err != nil {
+	if err := CreateMeeting(ctx, m); err != nil {
 		http.Error(w, "모임 저장 실패: "+err.Error(), 500)
 		return
 	}
@@ -353,24 +801,87 @@ func MeetingDetailHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	ctx := r.Context()
+	meeting, _ := GetMeetingByID(ctx, meetingID)
+	// 모임 생성시 저장한 분석 기록 (긍정 댓글 작성자가 참가 자격 명단)
+	var analysis *AnalysisRecord
+	var analysisErr error
+	var eligible []string
+	if meeting.AnalysisID != "" {
+		if analysis, analysisErr = GetAnalysis(ctx, meeting.AnalysisID); analysisErr == nil {
+			eligible = analysis.Result.EligibleAuthors()
+		} else {
+			log.Printf("모임 %s 분석 기록 %s 조회 실패: %v", meetingID, meeting.AnalysisID, analysisErr)
+		}
+	}
 	if r.Method == http.MethodPost {
-		// 참가자 신청 저장(스켈레톤)
-		name := r.FormValue("name")
-		email := r.FormValue("email")
-		// TODO: 참가자 Firestore 저장
-		_ = name
-		_ = email
-		// 리다이렉트
+		name := strings.TrimSpace(r.FormValue("name"))
+		email := strings.TrimSpace(r.FormValue("email"))
+		if name == "" || email == "" {
+			http.Error(w, "이름(유튜브 닉네임)과 이메일을 입력하세요.", 400)
+			return
+		}
+		if status, msg := meetingEligibility(meeting, analysisErr, eligible, name); status != 0 {
+			http.Error(w, msg, status)
+			return
+		}
+		if err := SaveParticipant(ctx, Participant{MeetingID: meetingID, Name: name, Email: email, CreatedAt: time.Now().Unix()}); err != nil {
+			http.Error(w, "참가 신청 저장 실패: "+err.Error(), 500)
+			return
+		}
 		http.Redirect(w, r, "/meeting?id="+meetingID, http.StatusSeeOther)
 		return
 	}
-	meeting, _ := GetMeetingByID(ctx, meetingID)
 	participants, _ := GetParticipants(ctx, meetingID)
-	tmpl, _ := template.ParseFiles("web/templates/meeting.html")
-	tmpl.Execute(w, map[string]interface{}{
+	// 주기적 재분석 결과로 감성 변화 차트 생성
+	driftChart, driftError := "", ""
+	var snapshots []SentimentSnapshot
+	if meeting.VideoID != "" {
+		var err error
+		snapshots, err = GetSentimentSnapshots(ctx, meeting.VideoID)
+		if err != nil {
+			log.Printf("모임 %s 감성 스냅샷 조회 실패: %v", meeting.MeetingID, err)
+			driftError = "감성 변화 기록을 불러오지 못했습니다."
+		}
+	}
+	if len(snapshots) > 0 && GenerateDriftChart(snapshots, "web/static/drift_"+meeting.MeetingID+".html") == nil {
+		driftChart = "/static/drift_" + meeting.MeetingID + ".html"
+	}
+	data := map[string]interface{}{
 		"Meeting":      meeting,
 		"Participants": participants,
-	})
+		"Eligible":     eligible,
+		"Snapshots":    snapshots,
+		"DriftChart":   driftChart,
+		"DriftError":   driftError,
+		"DriftAlert":   meeting.DriftAlert && meeting.CreatorID != "" && meeting.CreatorID == currentUserID(r),
+	}
+	if analysis != nil {
+		data["AnalysisID"] = analysis.ID
+		data["Shares"] = analysis.Result.Shares
+		data["PosCount"] = analysis.Result.PosCount
+		data["NegCount"] = analysis.Result.NegCount
+		data["NeuCount"] = analysis.Result.NeuCount
+		data["TotalCount"] = analysis.Result.TotalCount
+		data["Insight"] = analysis.Result.Insight
+		data["PieChartPath"] = "/analysis/chart?id=" + analysis.ID + "&type=" + ChartPie
+	}
+	tmpl, _ := template.ParseFiles("web/templates/meeting.html")
+	tmpl.Execute(w, data)
+}
+
+// meetingEligibility: 참가 신청 자격 확인 (허용이면 status 0).
+// 분석 기록이 연결된 모임은 기록을 불러오지 못하면 누구나 신청되지 않도록 막음
+func meetingEligibility(meeting Meeting, analysisErr error, eligible []string, name string) (int, string) {
+	if meeting.AnalysisID == "" {
+		return 0, ""
+	}
+	if analysisErr != nil {
+		return 500, "참가 자격 명단을 불러오지 못했습니다. 잠시 후 다시 시도하세요."
+	}
+	if !containsString(eligible, name) {
+		return 403, "긍정 댓글을 남긴 시청자만 참가 신청할 수 있습니다."
+	}
+	return 0, ""
 }
 
 // 모임 관리(참가자 승인/거절, 다운로드 등)
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// 분석 기록 목록에 보여줄 최대 개수
+const maxHistoryEntries = 50
+
+// 결과 차트 종류 (/analysis/chart?type=)
+const (
+	ChartWordCloud = "wordcloud"
+	ChartPie       = "pie"
+	ChartEmotion   = "emotion"
+	ChartTimeline  = "timeline"
+)
+
+// AnalysisRecord: 저장된 분석 결과 (analyses 컬렉션, 문서 ID = ID)
+type AnalysisRecord struct {
+	ID            string          `firestore:"id" json:"id"`
+	UserID        string          `firestore:"userId" json:"userId"`
+	VideoID       string          `firestore:"videoId" json:"videoId"`
+	Title         string          `firestore:"title" json:"title"`
+	Options       AnalysisOptions `firestore:"options" json:"options"`
+	Model         string          `firestore:"model" json:"model"`                 // 감성분석 모델 (오프라인이면 offline)
+	FallbackCount int             `firestore:"fallbackCount" json:"fallbackCount"` // OpenAI 실패로 사전 라벨을 쓴 댓글 수 (0보다 크면 섞인 결과)
+	CreatedAt     int64           `firestore:"createdAt" json:"createdAt"`
+	Result        *AnalysisResult `firestore:"result" json:"result"`
+}
+
+// HistoryEntry: 분석 기록 목록 항목 (결과 본문 없이 요약만)
+type HistoryEntry struct {
+	ID         string `json:"id"`
+	VideoID    string `json:"videoId"`
+	Title      string `json:"title"`
+	CreatedAt  int64  `json:"createdAt"`
+	PosCount   int    `json:"posCount"`
+	NegCount   int    `json:"negCount"`
+	NeuCount   int    `json:"neuCount"`
+	TotalCount int    `json:"totalCount"`
+}
+
+// SaveAnalysis: 분석 결과를 사용자 기록으로 저장하고 기록 ID 반환.
+// 캐시에서 재사용한 결과를 같은 사용자가 이미 저장했으면 새 문서를 만들지 않고 그 기록 ID 반환
+func SaveAnalysis(ctx context.Context, userID string, opt AnalysisOptions, res *AnalysisResult) (string, error) {
+	if id := cachedRecordID(userID, opt, res); id != "" {
+		return id, nil
+	}
+	model := currentOpenAIModel()
+	if opt.Offline {
+		model = "offline"
+	}
+	id, err := newRecordID()
+	if err != nil {
+		return "", err
+	}
+	rec := AnalysisRecord{
+		ID:            id,
+		UserID:        userID,
+		VideoID:       res.VideoID,
+		Title:         res.Meta.Title,
+		Options:       opt,
+		Model:         model,
+		FallbackCount: res.FallbackCount,
+		CreatedAt:     time.Now().Unix(),
+		Result:        res,
+	}
+	if _, err := firestoreClient.Collection("analyses").Doc(rec.ID).Set(ctx, rec); err != nil {
+		return "", err
+	}
+	rememberRecordID(userID, opt, res, rec.ID)
+	return rec.ID, nil
+}
+
+// GetAnalysis: 기록 ID로 저장된 분석 결과 조회
+func GetAnalysis(ctx context.Context, id string) (*AnalysisRecord, error) {
+	if id == "" {
+		return nil, fmt.Errorf("분석 기록 ID가 없음")
+	}
+	doc, err := firestoreClient.Collection("analyses").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rec AnalysisRecord
+	if err := doc.DataTo(&rec); err != nil {
+		return nil, err
+	}
+	if rec.Result == nil {
+		return nil, fmt.Errorf("분석 기록 %s에 결과가 없음", id)
+	}
+	return &rec, nil
+}
+
+// analysisMatches: 분석 기록이 해당 영상을 해당 사용자가 분석한 것인지 (모임에 연결할 기록 검증)
+func analysisMatches(rec *AnalysisRecord, videoID, userID string) bool {
+	return rec != nil && rec.VideoID == videoID && rec.UserID == userID
+}
+
+// 기록 목록에 필요한 필드 (결과 본문 전체를 읽지 않도록 요약 값만 조회)
+var historySummaryFields = []string{
+	"id", "userId", "videoId", "title", "createdAt",
+	"result.PosCount", "result.NegCount", "result.NeuCount", "result.TotalCount",
+}
+
+// ListAnalyses: 사용자의 분석 기록을 최신순으로 최대 maxHistoryEntries개 조회 (videoID가 있으면 그 영상만).
+// 기록 ID가 공개 리포트 링크이므로 다른 사용자의 기록은 조회하지 않음
+func ListAnalyses(ctx context.Context, userID, videoID string) ([]HistoryEntry, error) {
+	if userID == "" {
+		return []HistoryEntry{}, nil
+	}
+	q := firestoreClient.Collection("analyses").Select(historySummaryFields...).Where("userId", "==", userID)
+	if videoID != "" {
+		q = q.Where("videoId", "==", videoID)
+	}
+	docs, err := q.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]AnalysisRecord, 0, len(docs))
+	for _, doc := range docs {
+		var rec AnalysisRecord
+		if err := doc.DataTo(&rec); err == nil {
+			records = append(records, rec)
+		}
+	}
+	return historyEntries(records, userID), nil
+}
+
+// historyEntries: userID의 기록 중 결과가 있는 것만 요약해 최신순으로 최대 maxHistoryEntries개 반환
+func historyEntries(records []AnalysisRecord, userID string) []HistoryEntry {
+	entries := make([]HistoryEntry, 0, len(records))
+	for _, rec := range records {
+		if rec.Result == nil || rec.UserID != userID {
+			continue
+		}
+		entries = append(entries, HistoryEntry{
+			ID:         rec.ID,
+			VideoID:    rec.VideoID,
+			Title:      rec.Title,
+			CreatedAt:  rec.CreatedAt,
+			PosCount:   rec.Result.PosCount,
+			NegCount:   rec.Result.NegCount,
+			NeuCount:   rec.Result.NeuCount,
+			TotalCount: rec.Result.TotalCount,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt > entries[j].CreatedAt })
+	if len(entries) > maxHistoryEntries {
+		entries = entries[:maxHistoryEntries]
+	}
+	return entries
+}
+
+// RenderResultChart: 분석 결과 차트(wordcloud, pie, emotion, timeline)를 HTML로 출력
+func RenderResultChart(w io.Writer, res *AnalysisResult, kind string) error {
+	switch kind {
+	case ChartWordCloud:
+		return wordCloud(res.WordFreq).Render(w)
+	case ChartPie:
+		return labelPie("감성분석", res.Labels).Render(w)
+	case ChartEmotion:
+		if res.Emotions == nil {
+			return fmt.Errorf("감정 분석 결과가 없음")
+		}
+		return labelPie("감정분석", res.EmotionLabels()).Render(w)
+	case ChartTimeline:
+		return timelineChart(res.Timeline).Render(w)
+	}
+	return fmt.Errorf("알 수 없는 차트 종류: %s", kind)
+}
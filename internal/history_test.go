@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryEntries(t *testing.T) {
+	records := []AnalysisRecord{
+		{ID: "old", UserID: "u1", CreatedAt: 100, Result: &AnalysisResult{PosCount: 1, TotalCount: 1}},
+		{ID: "broken", UserID: "u1", CreatedAt: 500}, // 결과가 없는 기록은 제외
+		{ID: "new", UserID: "u1", CreatedAt: 300, Result: &AnalysisResult{NegCount: 2, TotalCount: 2}},
+		{ID: "other", UserID: "u2", CreatedAt: 400, Result: &AnalysisResult{}}, // 다른 사용자의 기록은 제외
+		{ID: "mid", UserID: "u1", CreatedAt: 200, Result: &AnalysisResult{}},
+	}
+	entries := historyEntries(records, "u1")
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	if got := strings.Join(ids, ","); got != "new,mid,old" {
+		t.Errorf("기록 순서 %s, 기대값 new,mid,old", got)
+	}
+	if entries[0].NegCount != 2 || entries[0].TotalCount != 2 {
+		t.Errorf("요약 값이 다름: %+v", entries[0])
+	}
+
+	// 최대 개수를 넘으면 최신 기록만 남김
+	many := make([]AnalysisRecord, maxHistoryEntries+5)
+	for i := range many {
+		many[i] = AnalysisRecord{UserID: "u1", CreatedAt: int64(i), Result: &AnalysisResult{}}
+	}
+	entries = historyEntries(many, "u1")
+	if len(entries) != maxHistoryEntries {
+		t.Fatalf("기록 %d개, 기대값 %d개", len(entries), maxHistoryEntries)
+	}
+	if entries[0].CreatedAt != int64(maxHistoryEntries+4) || entries[len(entries)-1].CreatedAt != 5 {
+		t.Errorf("잘린 범위가 다름: 처음 %d, 마지막 %d", entries[0].CreatedAt, entries[len(entries)-1].CreatedAt)
+	}
+}
+
+func TestHistoryRequiresOwner(t *testing.T) {
+	// 다른 사용자의 기록 ID(공개 리포트 링크)가 영상별 목록으로 새어 나가면 안 됨
+	records := []AnalysisRecord{
+		{ID: "mine", UserID: "u1", VideoID: "dQw4w9WgXcQ", Result: &AnalysisResult{}},
+		{ID: "theirs", UserID: "u2", VideoID: "dQw4w9WgXcQ", Result: &AnalysisResult{}},
+	}
+	if entries := historyEntries(records, "u1"); len(entries) != 1 || entries[0].ID != "mine" {
+		t.Errorf("u1 기록 목록 = %+v, 기대값 mine만", entries)
+	}
+	if entries := historyEntries(records, ""); len(entries) != 0 {
+		t.Errorf("로그인하지 않았는데 기록 %+v", entries)
+	}
+	// 로그인하지 않으면 영상별 목록도 비어 있음
+	rec := httptest.NewRecorder()
+	HistoryAPIHandler(rec, httptest.NewRequest(http.MethodGet, "/api/history?video_id=dQw4w9WgXcQ", nil))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Errorf("비로그인 영상별 기록 응답 %d %s, 기대값 200 []", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRenderResultChart(t *testing.T) {
+	res := &AnalysisResult{
+		WordFreq:   map[string]int{"노래": 3, "좋다": 2},
+		Labels:     []string{"긍정", "부정", "긍정"},
+		Sentiments: []SentimentResult{{Emotion: "기쁨"}, {Emotion: "분노"}},
+		Emotions:   map[string]int{"기쁨": 1, "분노": 1},
+		Timeline:   SentimentTimeline{Unit: "hour", Buckets: []TimelineBucket{{Label: "0시간", PosCount: 1}}},
+	}
+	for _, kind := range []string{ChartWordCloud, ChartPie, ChartEmotion, ChartTimeline} {
+		var buf bytes.Buffer
+		if err := RenderResultChart(&buf, res, kind); err != nil {
+			t.Errorf("%s 차트 렌더링 실패: %v", kind, err)
+			continue
+		}
+		if !strings.Contains(buf.String(), "echarts") {
+			t.Errorf("%s 차트에 echarts 스크립트가 없음", kind)
+		}
+	}
+	if err := RenderResultChart(&bytes.Buffer{}, res, "bar"); err == nil {
+		t.Error("알 수 없는 차트 종류인데 에러가 없음")
+	}
+	if err := RenderResultChart(&bytes.Buffer{}, &AnalysisResult{}, ChartEmotion); err == nil {
+		t.Error("감정 분석 결과가 없는데 에러가 없음")
+	}
+}
+
+func TestSaveAnalysisReusesCachedRecord(t *testing.T) {
+	opt := DefaultAnalysisOptions()
+	res := &AnalysisResult{VideoID: "cachedvid01"}
+	key := analysisCacheKey(res.VideoID, opt)
+	analysisCacheMu.Lock()
+	analysisCache[key] = cachedAnalysis{res: res, expires: time.Now().Add(defaultAnalysisCacheTTL)}
+	analysisCacheMu.Unlock()
+	defer func() {
+		analysisCacheMu.Lock()
+		delete(analysisCache, key)
+		analysisCacheMu.Unlock()
+	}()
+
+	if id := cachedRecordID("u1", opt, res); id != "" {
+		t.Fatalf("저장 전 기록 ID %q, 기대값 없음", id)
+	}
+	rememberRecordID("u1", opt, res, "rec1")
+	// 캐시 적중시 Firestore에 새 문서를 만들지 않고 같은 기록 ID 반환
+	if id, err := SaveAnalysis(context.Background(), "u1", opt, res); err != nil || id != "rec1" {
+		t.Errorf("SaveAnalysis = (%q, %v), 기대값 rec1", id, err)
+	}
+	if id := cachedRecordID("u2", opt, res); id != "" {
+		t.Errorf("다른 사용자에게 기록 ID %q를 재사용함", id)
+	}
+	if id := cachedRecordID("u1", opt, &AnalysisResult{VideoID: res.VideoID}); id != "" {
+		t.Errorf("새로 분석한 결과에 기록 ID %q를 재사용함", id)
+	}
+}
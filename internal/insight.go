@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// 대표 댓글로 모델에 보내는 라벨별 최대 개수와 댓글당 최대 글자 수
+const (
+	insightSamplesPerLabel = 5
+	insightMaxCommentRunes = 200
+)
+
+// InsightInput: 인사이트 생성에 필요한 계산 결과 (감성 분포, 댓글, 키워드)
+type InsightInput struct {
+	Keywords []string
+	Comments []Comment
+	Labels   []string // Comments와 같은 순서의 감성 라벨
+	PosCount int
+	NegCount int
+	NeuCount int
+}
+
+// InsightQuote: 인사이트에 인용된 댓글 (Ref는 분석 댓글 목록의 1부터 시작하는 번호)
+type InsightQuote struct {
+	Ref    int    `json:"ref"`
+	Author string `json:"author"`
+	Text   string `json:"text"`
+	Reason string `json:"reason"`
+}
+
+// Insight: 댓글 여론 요약 (전체 분위기, 주요 토픽, 논쟁점, 주목할 댓글)
+type Insight struct {
+	OverallMood   string         `json:"overallMood"`
+	MainTopics    []string       `json:"mainTopics"`
+	Controversies []string       `json:"controversies"`
+	NotableQuotes []InsightQuote `json:"notableQuotes"`
+}
+
+// String: 템플릿에서 {{.Insight}}로 출력할 때 쓰는 요약 문장
+func (in Insight) String() string {
+	var sb strings.Builder
+	sb.WriteString(in.OverallMood)
+	if len(in.MainTopics) > 0 {
+		sb.WriteString("\n주요 토픽: " + strings.Join(in.MainTopics, ", "))
+	}
+	if len(in.Controversies) > 0 {
+		sb.WriteString("\n논쟁점: " + strings.Join(in.Controversies, ", "))
+	}
+	for _, q := range in.NotableQuotes {
+		sb.WriteString(fmt.Sprintf("\n[#%d] %s - %s", q.Ref, q.Text, q.Reason))
+	}
+	return sb.String()
+}
+
+// 모델 응답 형식: 인용은 댓글 번호와 이유만 받고 본문은 원본 댓글에서 채운다
+type insightResponse struct {
+	OverallMood   string   `json:"overallMood"`
+	MainTopics    []string `json:"mainTopics"`
+	Controversies []string `json:"controversies"`
+	NotableQuotes []struct {
+		Ref    int    `json:"ref"`
+		Reason string `json:"reason"`
+	} `json:"notableQuotes"`
+}
+
+var insightSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"overallMood":   map[string]interface{}{"type": "string"},
+		"mainTopics":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		"controversies": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		"notableQuotes": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"ref":    map[string]interface{}{"type": "integer"},
+					"reason": map[string]interface{}{"type": "string"},
+				},
+				"required":             []string{"ref", "reason"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"overallMood", "mainTopics", "controversies", "notableQuotes"},
+	"additionalProperties": false,
+}
+
+// GenerateInsight: 감성 분포, 대표 긍정/부정 댓글, 키워드를 근거로 구조화된 인사이트 생성
+func GenerateInsight(in InsightInput) (Insight, error) {
+	total := in.PosCount + in.NegCount + in.NeuCount
+	if total == 0 {
+		return Insight{OverallMood: "분석할 댓글이 없습니다."}, nil
+	}
+	samples := append(representativeComments(in.Comments, in.Labels, "긍정", insightSamplesPerLabel),
+		representativeComments(in.Comments, in.Labels, "부정", insightSamplesPerLabel)...)
+	allowed := make(map[int]bool, len(samples))
+
+	var sb strings.Builder
+	sb.WriteString("아래 유튜브 댓글 분석 결과만 근거로 여론을 요약해줘. 주어지지 않은 수치나 내용은 지어내지 마.\n")
+	sb.WriteString(fmt.Sprintf("- 감성 분포(총 %d개): 긍정 %d, 부정 %d, 중립 %d\n", total, in.PosCount, in.NegCount, in.NeuCount))
+	sb.WriteString("- 키워드: " + strings.Join(in.Keywords, ", ") + "\n")
+	sb.WriteString("- 대표 댓글:\n")
+	for _, idx := range samples {
+		allowed[idx+1] = true
+		sb.WriteString(fmt.Sprintf("  [#%d] (%s) %s\n", idx+1, in.Labels[idx], truncateRunes(in.Comments[idx].Text, insightMaxCommentRunes)))
+	}
+	sb.WriteString("overallMood에는 전체 분위기를 2~3문장 한글로, mainTopics에는 주요 토픽, controversies에는 논쟁점(없으면 빈 배열), " +
+		"notableQuotes에는 위 대표 댓글 중 주목할 댓글 번호(ref)와 이유를 최대 3개 넣어줘.")
+
+	var res insightResponse
+	validate := func() error {
+		if strings.TrimSpace(res.OverallMood) == "" {
+			return fmt.Errorf("overallMood가 비어 있음")
+		}
+		for _, q := range res.NotableQuotes {
+			if !allowed[q.Ref] {
+				return fmt.Errorf("notableQuotes의 ref %d는 대표 댓글 번호가 아님", q.Ref)
+			}
+		}
+		return nil
+	}
+	if err := chatJSON(sb.String(), "insight", insightSchema, &res, validate); err != nil {
+		return fallbackInsight(in), err
+	}
+	insight := Insight{
+		OverallMood:   strings.TrimSpace(res.OverallMood),
+		MainTopics:    res.MainTopics,
+		Controversies: res.Controversies,
+	}
+	for _, q := range res.NotableQuotes {
+		c := in.Comments[q.Ref-1]
+		insight.NotableQuotes = append(insight.NotableQuotes, InsightQuote{Ref: q.Ref, Author: c.Author, Text: c.Text, Reason: q.Reason})
+	}
+	return insight, nil
+}
+
+// fallbackInsight: 모델 호출 실패시 계산된 수치만으로 만든 요약
+func fallbackInsight(in InsightInput) Insight {
+	return Insight{
+		OverallMood: fmt.Sprintf("긍정 %d, 부정 %d, 중립 %d개의 댓글이 분석되었습니다.", in.PosCount, in.NegCount, in.NeuCount),
+		MainTopics:  in.Keywords,
+	}
+}
+
+// representativeComments: 해당 라벨 댓글 중 공감(좋아요+답글)이 많고 내용이 긴 순서로 최대 n개의 인덱스 반환
+func representativeComments(comments []Comment, labels []string, label string, n int) []int {
+	idxs := make([]int, 0)
+	for i, l := range labels {
+		if l == label && i < len(comments) {
+			idxs = append(idxs, i)
+		}
+	}
+	sort.SliceStable(idxs, func(a, b int) bool {
+		ca, cb := comments[idxs[a]], comments[idxs[b]]
+		if ca.Engagement() != cb.Engagement() {
+			return ca.Engagement() > cb.Engagement()
+		}
+		return utf8.RuneCountInString(ca.Text) > utf8.RuneCountInString(cb.Text)
+	})
+	if len(idxs) > n {
+		idxs = idxs[:n]
+	}
+	return idxs
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
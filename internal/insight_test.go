@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepresentativeComments(t *testing.T) {
+	comments := []Comment{
+		{Text: "짧음", LikeCount: 5},
+		{Text: "조금 더 긴 댓글", LikeCount: 5},
+		{Text: "좋아요 많음", LikeCount: 10, ReplyCount: 2},
+		{Text: "부정 댓글", LikeCount: 100},
+		{Text: "공감 없음"},
+	}
+	labels := []string{"긍정", "긍정", "긍정", "부정", "긍정"}
+	cases := []struct {
+		label string
+		n     int
+		want  []int
+	}{
+		{"긍정", 5, []int{2, 1, 0, 4}}, // 공감 많은 순, 같으면 긴 순
+		{"긍정", 2, []int{2, 1}},
+		{"부정", 5, []int{3}},
+		{"중립", 5, []int{}},
+	}
+	for _, tc := range cases {
+		if got := representativeComments(comments, labels, tc.label, tc.n); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("representativeComments(%s, %d) = %v, 기대값 %v", tc.label, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestGenerateInsight(t *testing.T) {
+	in := InsightInput{
+		Keywords: []string{"노래", "편집"},
+		Comments: []Comment{
+			{Author: "가", Text: "노래 최고", LikeCount: 3},
+			{Author: "나", Text: "편집이 별로", LikeCount: 1},
+			{Author: "다", Text: "그냥 봄"},
+		},
+		Labels:   []string{"긍정", "부정", "중립"},
+		PosCount: 1, NegCount: 1, NeuCount: 1,
+	}
+	cases := []struct {
+		name      string
+		in        InsightInput
+		replies   []string
+		wantErr   bool
+		wantCalls int
+		want      Insight
+	}{
+		{
+			name:      "댓글 없음",
+			in:        InsightInput{},
+			replies:   []string{`{}`},
+			wantCalls: 0,
+			want:      Insight{OverallMood: "분석할 댓글이 없습니다."},
+		},
+		{
+			name:      "대표 댓글 인용",
+			in:        in,
+			replies:   []string{`{"overallMood":" 대체로 호평 ","mainTopics":["노래"],"controversies":[],"notableQuotes":[{"ref":2,"reason":"편집 비판"}]}`},
+			wantCalls: 1,
+			want: Insight{
+				OverallMood:   "대체로 호평",
+				MainTopics:    []string{"노래"},
+				Controversies: []string{},
+				NotableQuotes: []InsightQuote{{Ref: 2, Author: "나", Text: "편집이 별로", Reason: "편집 비판"}},
+			},
+		},
+		{
+			name:      "대표 댓글이 아닌 번호를 계속 인용하면 수치 요약으로 대체",
+			in:        in,
+			replies:   []string{`{"overallMood":"호평","mainTopics":[],"controversies":[],"notableQuotes":[{"ref":3,"reason":"중립"}]}`},
+			wantErr:   true,
+			wantCalls: maxJSONReask + 1,
+			want:      Insight{OverallMood: "긍정 1, 부정 1, 중립 1개의 댓글이 분석되었습니다.", MainTopics: []string{"노래", "편집"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var prompts []string
+			calls := stubChatCompletions(t, &prompts, tc.replies...)
+			got, err := GenerateInsight(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, 에러 기대 %v", err, tc.wantErr)
+			}
+			if *calls != tc.wantCalls {
+				t.Errorf("요청 %d번, 기대값 %d번", *calls, tc.wantCalls)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("인사이트 = %+v, 기대값 %+v", got, tc.want)
+			}
+			// 프롬프트에는 계산된 분포와 긍정/부정 대표 댓글만 들어감
+			if len(prompts) > 0 {
+				p := prompts[0]
+				if !strings.Contains(p, "긍정 1, 부정 1, 중립 1") || !strings.Contains(p, "[#1] (긍정) 노래 최고") || !strings.Contains(p, "[#2] (부정) 편집이 별로") {
+					t.Errorf("프롬프트에 분포나 대표 댓글이 없음:\n%s", p)
+				}
+				if strings.Contains(p, "[#3]") {
+					t.Errorf("중립 댓글이 대표 댓글에 포함됨:\n%s", p)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// 키워드 점수 방식
+const (
+	KeywordFreq  = "freq"  // 단순 빈도
+	KeywordTFIDF = "tfidf" // 이전 분석 영상들 대비 TF-IDF
+	KeywordLLR   = "llr"   // 이전 분석 영상들 대비 로그우도비(G²)
+)
+
+// 코퍼스에 영상당 저장하는 최대 단어 수, 배경 코퍼스로 불러오는 최대 영상 수
+const (
+	corpusTermsPerVideo = 500
+	corpusMaxVideos     = 200
+)
+
+// BackgroundCorpus: 이전에 분석한 영상들의 단어 통계
+type BackgroundCorpus struct {
+	DocCount   int            // 영상 수
+	DocFreq    map[string]int // 단어가 등장한 영상 수
+	TermFreq   map[string]int // 전체 단어 빈도
+	TotalTerms int
+}
+
+// NewBackgroundCorpus: 영상별 단어 빈도 맵들로 배경 코퍼스 구성
+func NewBackgroundCorpus(docs []map[string]int) BackgroundCorpus {
+	bg := BackgroundCorpus{DocFreq: map[string]int{}, TermFreq: map[string]int{}}
+	for _, doc := range docs {
+		bg.DocCount++
+		for w, n := range doc {
+			bg.DocFreq[w]++
+			bg.TermFreq[w] += n
+			bg.TotalTerms += n
+		}
+	}
+	return bg
+}
+
+// ScoreKeywords: 배경 코퍼스 대비 단어 점수 계산.
+// method가 freq이거나 알 수 없거나 배경 코퍼스가 비어 있으면 빈도 그대로
+func ScoreKeywords(freq map[string]int, bg BackgroundCorpus, method string) map[string]float64 {
+	if bg.DocCount == 0 {
+		method = KeywordFreq
+	}
+	scores := make(map[string]float64, len(freq))
+	total := 0
+	for _, n := range freq {
+		total += n
+	}
+	for w, n := range freq {
+		switch method {
+		case KeywordTFIDF:
+			tf := float64(n) / float64(total)
+			idf := math.Log(float64(1+bg.DocCount) / float64(1+bg.DocFreq[w]))
+			scores[w] = tf * idf
+		case KeywordLLR:
+			scores[w] = logLikelihood(n, total, bg.TermFreq[w], bg.TotalTerms)
+		default:
+			scores[w] = float64(n)
+		}
+	}
+	return scores
+}
+
+// logLikelihood: Dunning 로그우도비(G²). 배경보다 덜 쓰인 단어는 음수
+func logLikelihood(a, c, b, d int) float64 {
+	fa, fb, fc, fd := float64(a), float64(b), float64(c), float64(d)
+	if fc+fd == 0 {
+		return 0
+	}
+	e1 := fc * (fa + fb) / (fc + fd)
+	e2 := fd * (fa + fb) / (fc + fd)
+	g2 := 0.0
+	if fa > 0 {
+		g2 += fa * math.Log(fa/e1)
+	}
+	if fb > 0 {
+		g2 += fb * math.Log(fb/e2)
+	}
+	g2 *= 2
+	if fd > 0 && fa/fc < fb/fd {
+		return -g2
+	}
+	return g2
+}
+
+// TopNScored: 점수 맵에서 상위 N개 단어 (동점이면 사전순)
+func TopNScored(scores map[string]float64, n int) []string {
+	words := make([]string, 0, len(scores))
+	for w := range scores {
+		words = append(words, w)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if scores[words[i]] != scores[words[j]] {
+			return scores[words[i]] > scores[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+type corpusDoc struct {
+	VideoID   string         `firestore:"videoId"`
+	Terms     map[string]int `firestore:"terms"`
+	CreatedAt int64          `firestore:"createdAt"`
+}
+
+// LoadBackgroundCorpus: 최근 분석한 영상들의 단어 빈도로 배경 코퍼스 구성 (excludeVideoID 제외)
+func LoadBackgroundCorpus(ctx context.Context, excludeVideoID string) (BackgroundCorpus, error) {
+	if firestoreClient == nil {
+		return NewBackgroundCorpus(nil), nil
+	}
+	docs, err := firestoreClient.Collection("keywordCorpus").OrderBy("createdAt", firestore.Desc).Limit(corpusMaxVideos).Documents(ctx).GetAll()
+	if err != nil {
+		return NewBackgroundCorpus(nil), err
+	}
+	freqs := make([]map[string]int, 0, len(docs))
+	for _, doc := range docs {
+		var cd corpusDoc
+		doc.DataTo(&cd)
+		if cd.VideoID == excludeVideoID {
+			continue
+		}
+		freqs = append(freqs, cd.Terms)
+	}
+	return NewBackgroundCorpus(freqs), nil
+}
+
+// AddToCorpus: 분석한 영상의 상위 단어 빈도를 배경 코퍼스에 저장 (영상 ID 기준으로 덮어씀)
+func AddToCorpus(ctx context.Context, videoID string, freq map[string]int) error {
+	if firestoreClient == nil || videoID == "" {
+		return nil
+	}
+	terms := map[string]int{}
+	for _, w := range TopNWords(freq, corpusTermsPerVideo) {
+		terms[w] = freq[w]
+	}
+	_, err := firestoreClient.Collection("keywordCorpus").Doc(videoID).Set(ctx, corpusDoc{
+		VideoID:   videoID,
+		Terms:     terms,
+		CreatedAt: time.Now().Unix(),
+	})
+	return err
+}
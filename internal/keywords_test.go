@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestScoreKeywordsAgainstBackground(t *testing.T) {
+	bg := NewBackgroundCorpus([]map[string]int{
+		{"노래": 10, "좋다": 20, "편집": 3},
+		{"노래": 8, "좋다": 15, "게임": 7},
+		{"좋다": 12, "먹방": 9},
+	})
+	freq := map[string]int{"좋다": 30, "노래": 10, "콘서트": 8}
+	for _, method := range []string{KeywordTFIDF, KeywordLLR} {
+		got := TopNScored(ScoreKeywords(freq, bg, method), 1)
+		if !reflect.DeepEqual(got, []string{"콘서트"}) {
+			t.Errorf("%s 상위 키워드 = %v, want [콘서트]", method, got)
+		}
+	}
+	got := TopNScored(ScoreKeywords(freq, bg, KeywordFreq), 1)
+	if !reflect.DeepEqual(got, []string{"좋다"}) {
+		t.Errorf("freq 상위 키워드 = %v, want [좋다]", got)
+	}
+}
+
+func TestRemoveStopwords(t *testing.T) {
+	freq := map[string]int{"진짜": 5, "the": 3, "콘서트": 2, "치킨": 1}
+	got := RemoveStopwords(freq, Stopwords([]string{"ko", "en"}, ParseWordList("치킨, ")))
+	want := map[string]int{"콘서트": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveStopwords = %v, want %v", got, want)
+	}
+}
+
+func TestChannelStopwordsPerUser(t *testing.T) {
+	if channelStopwordsDocID("u1", "UCabc") == channelStopwordsDocID("u2", "UCabc") {
+		t.Error("사용자가 달라도 같은 채널 불용어 문서를 씀")
+	}
+	// 로그인하지 않은 요청은 저장/조회 불가
+	rec := httptest.NewRecorder()
+	StopwordsAPIHandler(rec, httptest.NewRequest(http.MethodPost, "/api/stopwords?channel_id=UCabc&words=a", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("세션 없는 불용어 저장 응답 코드 = %d, 기대값 401", rec.Code)
+	}
+}
+
+func TestCountWordsByLanguage(t *testing.T) {
+	comments := []Comment{
+		{Text: "이번 video 진짜 최고", Language: "ko"},
+		{Text: "this video is great", Language: "en"},
+	}
+	got := CountWordsByLanguage(comments, []string{"최고"})
+	// 한국어 댓글의 "video"는 영어 불용어로 지우지 않음, "진짜"(한국어 불용어)와 추가 불용어 "최고"는 제거
+	if got["video"] != 1 {
+		t.Errorf("한국어 댓글의 video 빈도 = %d, 기대값 1 (%v)", got["video"], got)
+	}
+	for _, w := range []string{"진짜", "최고", "this", "is"} {
+		if got[w] != 0 {
+			t.Errorf("불용어 %q가 남아 있음: %v", w, got)
+		}
+	}
+	if got["great"] != 1 {
+		t.Errorf("great 빈도 = %d (%v)", got["great"], got)
+	}
+}
@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 언어 코드별 표시 이름
+var languageNames = map[string]string{
+	"ko":  "한국어",
+	"en":  "영어",
+	"ja":  "일본어",
+	"zh":  "중국어",
+	"es":  "스페인어",
+	"pt":  "포르투갈어",
+	"fr":  "프랑스어",
+	"de":  "독일어",
+	"id":  "인도네시아어",
+	"vi":  "베트남어",
+	"ru":  "러시아어",
+	"th":  "태국어",
+	"ar":  "아랍어",
+	"und": "알 수 없음",
+}
+
+// 라틴 문자 언어 구분용 고빈도 단어
+var latinLanguageHints = map[string][]string{
+	"en": {"the", "and", "is", "you", "this", "that", "it", "of", "to", "love", "so", "my", "i", "are", "with", "what"},
+	"es": {"el", "la", "que", "de", "y", "es", "los", "las", "muy", "por", "una", "con", "para", "te", "amo", "mi"},
+	"pt": {"o", "que", "de", "e", "é", "não", "muito", "uma", "com", "para", "você", "eu", "amo", "meu", "isso"},
+	"fr": {"le", "la", "les", "et", "est", "je", "tu", "que", "une", "des", "pour", "pas", "trop", "c'est", "très"},
+	"de": {"der", "die", "das", "und", "ist", "ich", "nicht", "ein", "eine", "sehr", "du", "mit", "auf", "für"},
+	"id": {"yang", "dan", "ini", "itu", "aku", "kamu", "tidak", "sangat", "dari", "untuk", "bagus", "banget", "sih"},
+	"vi": {"và", "là", "của", "có", "không", "một", "quá", "em", "anh", "những", "này", "được", "rất"},
+}
+
+// LanguageName: 언어 코드의 표시 이름
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// DetectLanguage: 문자 체계와 고빈도 단어로 댓글 언어 추정 (판단 불가시 "und")
+func DetectLanguage(text string) string {
+	counts := map[string]int{}
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		}
+	}
+	// 한자는 가나와 함께 쓰이면 일본어, 단독이면 중국어
+	if counts["han"] > 0 {
+		if counts["ja"] > 0 {
+			counts["ja"] += counts["han"]
+		} else {
+			counts["zh"] += counts["han"]
+		}
+		delete(counts, "han")
+	}
+	best, bestCount := "und", 0
+	for _, script := range []string{"ko", "ja", "zh", "ru", "th", "ar", "latin"} {
+		if counts[script] > bestCount {
+			best, bestCount = script, counts[script]
+		}
+	}
+	if best == "latin" {
+		return detectLatinLanguage(text)
+	}
+	return best
+}
+
+// detectLatinLanguage: 라틴 문자 댓글은 고빈도 단어 일치 수로 언어 추정 (동점/불일치시 영어)
+func detectLatinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	best, bestScore := "en", 0
+	for _, lang := range []string{"en", "es", "pt", "fr", "de", "id", "vi"} {
+		score := 0
+		for _, w := range words {
+			if containsString(latinLanguageHints[lang], w) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
+
+// LanguageBreakdown: 언어별 댓글 수와 감성 분포
+type LanguageBreakdown struct {
+	Language string `json:"language"`
+	Name     string `json:"name"`
+	PosCount int    `json:"posCount"`
+	NegCount int    `json:"negCount"`
+	NeuCount int    `json:"neuCount"`
+	Total    int    `json:"total"`
+}
+
+// BreakdownByLanguage: 댓글 언어별 감성 집계 (댓글 많은 순)
+func BreakdownByLanguage(comments []Comment, labels []string) []LanguageBreakdown {
+	byLang := map[string]*LanguageBreakdown{}
+	for i, c := range comments {
+		lang := c.Language
+		if lang == "" {
+			lang = DetectLanguage(c.Text)
+		}
+		b, ok := byLang[lang]
+		if !ok {
+			b = &LanguageBreakdown{Language: lang, Name: LanguageName(lang)}
+			byLang[lang] = b
+		}
+		b.Total++
+		if i < len(labels) {
+			switch labels[i] {
+			case "긍정":
+				b.PosCount++
+			case "부정":
+				b.NegCount++
+			case "중립":
+				b.NeuCount++
+			}
+		}
+	}
+	out := make([]LanguageBreakdown, 0, len(byLang))
+	for _, b := range byLang {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Language < out[j].Language
+	})
+	return out
+}
+
+// FilterByLanguage: 지정한 언어의 댓글만 남김 (langs가 비어 있으면 그대로)
+func FilterByLanguage(comments []Comment, langs []string) []Comment {
+	if len(langs) == 0 {
+		return comments
+	}
+	out := make([]Comment, 0, len(comments))
+	for _, c := range comments {
+		if containsString(langs, c.Language) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		"노래 너무 좋아요 ㅠㅠ":                           "ko",
+		"This is the best song ever":             "en",
+		"Me encanta esta canción, es muy bonita": "es",
+		"この曲が大好きです":                              "ja",
+		"这首歌太好听了":                                "zh",
+		"Это лучшая песня":                       "ru",
+		"👍👍":                                     "und",
+	}
+	for text, want := range cases {
+		if got := DetectLanguage(text); got != want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestCJKBigramTokenizer(t *testing.T) {
+	got := CJKBigramTokenizer{}.Tokenize("大好き MV")
+	want := []string{"大好", "好き", "mv"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,214 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 스팸/유해 판정 사유
+const (
+	FlagDuplicate    = "중복"
+	FlagLinkOnly     = "링크만"
+	FlagPromotion    = "홍보"
+	FlagRepeatAuthor = "반복작성자"
+	FlagToxic        = "유해"
+)
+
+// 같은 작성자가 이보다 많이 쓴 댓글은 반복 작성으로 간주
+const maxCommentsPerAuthor = 3
+
+// OpenAI moderation 요청 한 번에 보낼 댓글 수와 요청 제한 시간
+const moderationBatchSize = 100
+
+var moderationClient = &http.Client{Timeout: 30 * time.Second}
+
+// 이 길이(글자 수) 이상인 댓글만 중복 검사 (짧은 "ㅋㅋ", "좋아요" 등은 자연스러운 중복)
+const minDuplicateRunes = 10
+
+// 홍보 문구는 자기 채널 홍보만 (맞구독, 구독 + 제/내 채널). "구독하고 갑니다" 같은 팬 댓글은 제외
+var (
+	linkRe      = regexp.MustCompile(`(?i)(https?://\S+|www\.\S+|<a\s[^>]*>.*?</a>)`)
+	promotionRe = regexp.MustCompile(`(?i)(맞구독|구독.{0,10}(제|내)\s*채널|제\s*채널|내\s*채널\s*(놀러|와|방문)|오픈\s*채팅|오픈톡|텔레그램|카톡\s*(아이디|id)|부업|고수익|수익\s*인증|무료\s*(상담|증정)|check out my channel|subscribe to my|sub4sub|t\.me/|bit\.ly/)`)
+	spaceRe     = regexp.MustCompile(`\s+`)
+)
+
+// 욕설 중 "존나", "씨발", "holy shit"처럼 칭찬에도 쓰는 강조 표현은 제외하고,
+// 대상을 향한 모욕(병신, 꺼져, fuck you 등)만 유해로 판정
+var toxicRe = regexp.MustCompile(`(?i)(병신|ㅂㅅ|좆같|좆까|개새끼|새끼야|꺼져|닥쳐|미친놈|미친년|느금|니\s*애미|\bbitch|\basshole|\bretard|\bfuck\s*(you|u|off)\b|\bstfu\b|piece\s+of\s+shit|\bshit\s+(video|channel|content)\b)`)
+
+// FlaggedComment: 스팸 또는 유해로 분류된 댓글과 사유
+type FlaggedComment struct {
+	Comment Comment  `json:"comment"`
+	Reasons []string `json:"reasons"`
+}
+
+// ModerationReport: 댓글 필터링 결과
+type ModerationReport struct {
+	Kept  []Comment        `json:"-"`
+	Spam  []FlaggedComment `json:"spam"`
+	Toxic []FlaggedComment `json:"toxic"`
+}
+
+// ModerationSummary: 결과 페이지/API에 보여줄 필터링 통계
+type ModerationSummary struct {
+	FetchedCount int              `json:"fetchedCount"`
+	SpamCount    int              `json:"spamCount"`
+	ToxicCount   int              `json:"toxicCount"`
+	Excluded     bool             `json:"excluded"` // 분석에서 제외했는지 여부
+	Spam         []FlaggedComment `json:"spam"`
+	Toxic        []FlaggedComment `json:"toxic"`
+}
+
+// Summary: 통계 요약 생성
+func (m ModerationReport) Summary(fetched int, excluded bool) ModerationSummary {
+	return ModerationSummary{
+		FetchedCount: fetched,
+		SpamCount:    len(m.Spam),
+		ToxicCount:   len(m.Toxic),
+		Excluded:     excluded,
+		Spam:         m.Spam,
+		Toxic:        m.Toxic,
+	}
+}
+
+// ModerateComments: 수집한 댓글에서 스팸(중복, 링크만, 홍보, 반복 작성자)과 유해 댓글을 분류
+func ModerateComments(comments []Comment) ModerationReport {
+	var report ModerationReport
+	seenText := map[string]bool{}
+	authorCount := map[string]int{}
+	for _, c := range comments {
+		var reasons []string
+		norm := normalizeForDuplicate(c.Text)
+		if utf8.RuneCountInString(norm) >= minDuplicateRunes {
+			if seenText[norm] {
+				reasons = append(reasons, FlagDuplicate)
+			}
+			seenText[norm] = true
+		}
+		hasLink := len(c.Links) > 0 || linkRe.MatchString(c.Text)
+		if hasLink && strings.TrimSpace(linkRe.ReplaceAllString(c.Text, "")) == "" {
+			reasons = append(reasons, FlagLinkOnly)
+		}
+		if promotionRe.MatchString(c.Text + " " + strings.Join(c.Links, " ")) {
+			reasons = append(reasons, FlagPromotion)
+		}
+		if c.Author != "" {
+			authorCount[c.Author]++
+			if authorCount[c.Author] > maxCommentsPerAuthor {
+				reasons = append(reasons, FlagRepeatAuthor)
+			}
+		}
+		if len(reasons) > 0 {
+			report.Spam = append(report.Spam, FlaggedComment{Comment: c, Reasons: reasons})
+			continue
+		}
+		if IsToxic(c.Text) {
+			report.Toxic = append(report.Toxic, FlaggedComment{Comment: c, Reasons: []string{FlagToxic}})
+			continue
+		}
+		report.Kept = append(report.Kept, c)
+	}
+	return report
+}
+
+// IsToxic: 욕설 사전으로 유해 댓글 판정 (OpenAI moderation은 샘플링 후 ApplyOpenAIModeration에서 한꺼번에 확인)
+func IsToxic(text string) bool {
+	return toxicRe.MatchString(text)
+}
+
+// ApplyOpenAIModeration: OPENAI_MODERATION=1이면 분석할 댓글을 OpenAI moderation API로 묶어서 확인해
+// 유해 댓글을 Toxic에 추가하고, exclude면 분석할 댓글에서 뺀 목록 반환 (API 실패시 그대로 반환)
+func (m *ModerationReport) ApplyOpenAIModeration(comments []Comment, exclude bool) []Comment {
+	if os.Getenv("OPENAI_MODERATION") != "1" || len(comments) == 0 {
+		return comments
+	}
+	texts := make([]string, len(comments))
+	for i, c := range comments {
+		texts[i] = c.Text
+	}
+	flagged, err := openAIModeration(texts)
+	if err != nil {
+		log.Printf("OpenAI moderation 실패: %v", err)
+		return comments
+	}
+	kept := make([]Comment, 0, len(comments))
+	for i, c := range comments {
+		if flagged[i] {
+			m.Toxic = append(m.Toxic, FlaggedComment{Comment: c, Reasons: []string{FlagToxic}})
+			if exclude {
+				continue
+			}
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// openAIModeration: OpenAI moderation API로 댓글들의 유해 여부 확인 (moderationBatchSize개씩 배열로 요청).
+// OPENAI_MODERATION_URL로 엔드포인트 변경 가능
+func openAIModeration(texts []string) ([]bool, error) {
+	url := os.Getenv("OPENAI_MODERATION_URL")
+	if url == "" {
+		url = "https://api.openai.com/v1/moderations"
+	}
+	flagged := make([]bool, 0, len(texts))
+	for start := 0; start < len(texts); start += moderationBatchSize {
+		end := start + moderationBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		batch, err := moderationBatch(url, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		flagged = append(flagged, batch...)
+	}
+	return flagged, nil
+}
+
+func moderationBatch(url string, texts []string) ([]bool, error) {
+	jsonBody, _ := json.Marshal(map[string]interface{}{"input": texts})
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("OPENAI_API_KEY"))
+	resp, err := moderationClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation API 오류: %s", resp.Status)
+	}
+	var result struct {
+		Results []struct {
+			Flagged bool `json:"flagged"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Results) != len(texts) {
+		return nil, fmt.Errorf("moderation 결과 수(%d)가 요청 수(%d)와 다름", len(result.Results), len(texts))
+	}
+	flagged := make([]bool, len(texts))
+	for i, r := range result.Results {
+		flagged[i] = r.Flagged
+	}
+	return flagged, nil
+}
+
+// normalizeForDuplicate: 대소문자/공백 차이를 무시하고 중복 비교
+func normalizeForDuplicate(text string) string {
+	return strings.TrimSpace(spaceRe.ReplaceAllString(strings.ToLower(text), " "))
+}
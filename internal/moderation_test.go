@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestModerateComments(t *testing.T) {
+	comments := []Comment{
+		{Author: "a", Text: "노래 진짜 좋네요 매일 듣고 있어요"},
+		{Author: "b", Text: "노래 진짜 좋네요  매일 듣고 있어요"},
+		{Author: "c", Text: "https://bit.ly/abcd"},
+		{Author: "d", Text: "맞구독 해요~ 제 채널도 놀러오세요"},
+		{Author: "e", Text: "이게 무슨 병신 같은 영상이냐"},
+		{Author: "i", Text: "구독하고 알림설정까지 했어요"}, // 팬 댓글은 홍보가 아님
+		{Author: "j", Text: "구독하고 갑니다"},
+		{Author: "k", Text: "구독 부탁드려요~ 내 채널에 커버 올렸어요"},
+		{Author: "f", Text: "ㅋㅋ"},
+		{Author: "g", Text: "ㅋㅋ"},
+		{Author: "h", Text: "첫 번째"},
+		{Author: "h", Text: "두 번째"},
+		{Author: "h", Text: "세 번째"},
+		{Author: "h", Text: "네 번째"},
+	}
+	report := ModerateComments(comments)
+	wantSpam := map[string]string{
+		"노래 진짜 좋네요  매일 듣고 있어요":    FlagDuplicate,
+		"https://bit.ly/abcd":     FlagLinkOnly,
+		"맞구독 해요~ 제 채널도 놀러오세요":     FlagPromotion,
+		"구독 부탁드려요~ 내 채널에 커버 올렸어요": FlagPromotion,
+		"네 번째": FlagRepeatAuthor,
+	}
+	if len(report.Spam) != len(wantSpam) {
+		t.Fatalf("스팸 개수 = %d, want %d (%+v)", len(report.Spam), len(wantSpam), report.Spam)
+	}
+	for _, f := range report.Spam {
+		want, ok := wantSpam[f.Comment.Text]
+		if !ok || !containsString(f.Reasons, want) {
+			t.Errorf("스팸 %q 사유 = %v, want %q", f.Comment.Text, f.Reasons, want)
+		}
+	}
+	if len(report.Toxic) != 1 || report.Toxic[0].Comment.Author != "e" {
+		t.Errorf("유해 댓글 = %+v", report.Toxic)
+	}
+	if len(report.Kept) != len(comments)-len(wantSpam)-1 {
+		t.Errorf("남은 댓글 수 = %d", len(report.Kept))
+	}
+}
+
+func TestIsToxic(t *testing.T) {
+	cases := []struct {
+		text string
+		want bool
+	}{
+		// 강조 표현으로 쓴 칭찬은 유해가 아님
+		{"존나 좋다", false},
+		{"씨발 노래 미쳤다 개좋아", false},
+		{"holy shit this is good", false},
+		{"this is fucking amazing", false},
+		{"Shitty camera but great song", false},
+		// 대상을 향한 모욕
+		{"이게 무슨 병신 같은 영상이냐", true},
+		{"꺼져라 진짜", true},
+		{"fuck you", true},
+		{"what a shit video", true},
+		{"you're a piece of shit", true},
+	}
+	for _, tc := range cases {
+		if got := IsToxic(tc.text); got != tc.want {
+			t.Errorf("IsToxic(%q) = %v, 기대값 %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestApplyOpenAIModeration(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		results := make([]map[string]bool, len(body.Input))
+		for i, text := range body.Input {
+			results[i] = map[string]bool{"flagged": strings.Contains(text, "나쁜말")}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer srv.Close()
+	t.Setenv("OPENAI_MODERATION", "1")
+	t.Setenv("OPENAI_MODERATION_URL", srv.URL)
+
+	comments := []Comment{{Text: "좋아요"}, {Text: "나쁜말 댓글"}, {Text: "최고"}}
+	var report ModerationReport
+	kept := report.ApplyOpenAIModeration(comments, true)
+	if requests != 1 {
+		t.Errorf("moderation 요청 수 = %d, 기대값 1 (배열로 한 번에 요청)", requests)
+	}
+	if len(kept) != 2 || len(report.Toxic) != 1 || report.Toxic[0].Comment.Text != "나쁜말 댓글" {
+		t.Errorf("남은 댓글 = %+v, 유해 댓글 = %+v", kept, report.Toxic)
+	}
+	report = ModerationReport{}
+	if kept := report.ApplyOpenAIModeration(comments, false); len(kept) != 3 || len(report.Toxic) != 1 {
+		t.Errorf("제외하지 않을 때 남은 댓글 %d개, 유해 댓글 %d개", len(kept), len(report.Toxic))
+	}
+
+	// API 오류 응답이면 댓글을 그대로 둠
+	fail := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer fail.Close()
+	t.Setenv("OPENAI_MODERATION_URL", fail.URL)
+	report = ModerationReport{}
+	if kept := report.ApplyOpenAIModeration(comments, true); len(kept) != 3 || len(report.Toxic) != 0 {
+		t.Errorf("API 오류시 남은 댓글 %d개, 유해 댓글 %d개", len(kept), len(report.Toxic))
+	}
+}
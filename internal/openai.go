@@ -3,95 +3,211 @@ package internal
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
+	"reflect"
 	"strings"
+	"sync/atomic"
 )
 
-var openAIModel = "gpt-4o" // 최신 모델 우선 적용, 실패시 gpt-3.5-turbo로 fallback
+// 최신 모델 우선 적용, 모델을 쓸 수 없다는 응답(4xx)을 받으면 이후로는 대체 모델 사용
+const (
+	primaryOpenAIModel  = "gpt-4o"
+	fallbackOpenAIModel = "gpt-3.5-turbo"
+)
+
+// 여러 분석이 동시에 호출하므로 대체 여부는 원자적으로 기록
+var primaryModelUnavailable atomic.Bool
+
+// currentOpenAIModel: 지금 호출에 쓸 chat 모델
+func currentOpenAIModel() string {
+	if primaryModelUnavailable.Load() {
+		return fallbackOpenAIModel
+	}
+	return primaryOpenAIModel
+}
+
+// 모델 응답이 스키마에 맞지 않을 때 다시 요청하는 최대 횟수
+const maxJSONReask = 2
+
+var sentimentLabels = []string{"긍정", "부정", "중립"}
+
+// 감정 분류 체계 (감정 분석 옵션 사용시)
+var emotionLabels = []string{"기쁨", "감사", "설렘", "애정", "놀람", "분노", "슬픔", "실망", "비꼼", "무감정"}
+
+// SentimentResult: 댓글 1개에 대한 감성분석 결과
+type SentimentResult struct {
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+	Emotion    string  `json:"emotion,omitempty"`
+	Sarcasm    bool    `json:"sarcasm,omitempty"`
+	Source     string  `json:"source,omitempty"` // 라벨 출처 (SourceOpenAI, SourceOffline)
+}
+
+// 감성 라벨 출처 (OpenAI 실패시 사전 기반 점수로 대체한 댓글 구분용)
+const (
+	SourceOpenAI  = "openai"
+	SourceOffline = "offline"
+)
+
+// Eligible: 모임 참가 자격 여부 (비꼬는 칭찬은 긍정으로 보지 않음)
+func (s SentimentResult) Eligible() bool {
+	return s.Label == "긍정" && !s.Sarcasm
+}
+
+func (s *SentimentResult) validate() error {
+	if !containsString(sentimentLabels, s.Label) {
+		return fmt.Errorf("label은 '긍정', '부정', '중립' 중 하나여야 함: %q", s.Label)
+	}
+	if s.Confidence < 0 || s.Confidence > 1 {
+		return fmt.Errorf("confidence는 0~1 사이여야 함: %v", s.Confidence)
+	}
+	return nil
+}
+
+func (s *SentimentResult) validateEmotion() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	if !containsString(emotionLabels, s.Emotion) {
+		return fmt.Errorf("emotion은 %s 중 하나여야 함: %q", strings.Join(emotionLabels, ", "), s.Emotion)
+	}
+	return nil
+}
+
+var sentimentSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"label":      map[string]interface{}{"type": "string", "enum": sentimentLabels},
+		"confidence": map[string]interface{}{"type": "number"},
+	},
+	"required":             []string{"label", "confidence"},
+	"additionalProperties": false,
+}
+
+var emotionSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"label":      map[string]interface{}{"type": "string", "enum": sentimentLabels},
+		"confidence": map[string]interface{}{"type": "number"},
+		"emotion":    map[string]interface{}{"type": "string", "enum": emotionLabels},
+		"sarcasm":    map[string]interface{}{"type": "boolean"},
+	},
+	"required":             []string{"label", "confidence", "emotion", "sarcasm"},
+	"additionalProperties": false,
+}
 
 // AnalyzeSentiment: 댓글 텍스트를 OpenAI로 감성분석 ('긍정', '부정', '중립' 중 하나)
 func AnalyzeSentiment(text string) (string, error) {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	prompt := "다음 문장의 감성을 '긍정', '부정', '중립' 중 하나로만 답해줘. 문장: " + text
-	body := map[string]interface{}{
-		"model": openAIModel,
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
-	}
-	jsonBody, _ := json.Marshal(body)
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonBody))
+	res, err := AnalyzeSentimentDetail(text, false)
 	if err != nil {
-		return "", err
+		return "분석불가", err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		// gpt-4o 실패시 gpt-3.5-turbo로 재시도
-		if openAIModel != "gpt-3.5-turbo" {
-			openAIModel = "gpt-3.5-turbo"
-			return AnalyzeSentiment(text)
+	return res.Label, nil
+}
+
+// AnalyzeSentimentDetail: 감성 라벨과 확신도(0~1)를 JSON 스키마로 받아 검증.
+// withEmotion이면 세부 감정과 비꼼(sarcasm) 여부도 함께 분류
+func AnalyzeSentimentDetail(text string, withEmotion bool) (SentimentResult, error) {
+	var res SentimentResult
+	if !withEmotion {
+		prompt := "다음 유튜브 댓글의 감성을 '긍정', '부정', '중립' 중 하나로 분류하고, 확신도를 0~1 사이 숫자로 JSON으로 답해줘.\n댓글: " + text
+		if err := chatJSON(prompt, "sentiment", sentimentSchema, &res, res.validate); err != nil {
+			return SentimentResult{}, err
 		}
-		return "", err
+		res.Source = SourceOpenAI
+		return res, nil
 	}
-	defer resp.Body.Close()
-	var result struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
+	prompt := "다음 유튜브 댓글의 감성을 '긍정', '부정', '중립' 중 하나로 분류하고, 확신도(0~1), 세부 감정(" +
+		strings.Join(emotionLabels, ", ") + " 중 하나), 비꼬는 말투인지(sarcasm)를 JSON으로 답해줘. " +
+		"겉으로 칭찬이지만 비꼬는 댓글은 sarcasm을 true로.\n댓글: " + text
+	if err := chatJSON(prompt, "emotion", emotionSchema, &res, res.validateEmotion); err != nil {
+		return SentimentResult{}, err
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
-	}
-	if len(result.Choices) > 0 {
-		label := strings.TrimSpace(result.Choices[0].Message.Content)
-		label = strings.ReplaceAll(label, ".", "") // 마침표 제거
-		if strings.Contains(label, "긍정") {
-			return "긍정", nil
-		} else if strings.Contains(label, "부정") {
-			return "부정", nil
-		} else if strings.Contains(label, "중립") {
-			return "중립", nil
+	if res.Emotion == "비꼼" {
+		res.Sarcasm = true
+	}
+	res.Source = SourceOpenAI
+	return res, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatJSON: JSON 응답 형식으로 chat completion 호출 후 out에 엄격하게 파싱/검증.
+// 응답이 잘못되면 오류 내용을 알려주고 최대 maxJSONReask번 다시 요청한다.
+func chatJSON(prompt, schemaName string, schema map[string]interface{}, out interface{}, validate func() error) error {
+	messages := []chatMessage{
+		{Role: "system", Content: "너는 반드시 주어진 JSON 스키마에 맞는 JSON 객체 하나로만 답한다."},
+		{Role: "user", Content: prompt},
+	}
+	var lastErr error
+	for attempt := 0; attempt <= maxJSONReask; attempt++ {
+		content, err := chatCompletion(messages, schemaName, schema)
+		if err != nil {
+			return err
 		}
-		return label, nil // 혹시 다른 답변이 오면 그대로 반환
+		// 이전 응답의 필드가 남지 않도록 매번 비운 뒤 파싱 (validate가 out을 보므로 제자리에서 초기화)
+		v := reflect.ValueOf(out).Elem()
+		v.Set(reflect.Zero(v.Type()))
+		if err := decodeStrict(content, out); err != nil {
+			lastErr = err
+		} else if err := validate(); err != nil {
+			lastErr = err
+		} else {
+			return nil
+		}
+		messages = append(messages,
+			chatMessage{Role: "assistant", Content: content},
+			chatMessage{Role: "user", Content: "응답이 올바르지 않아: " + lastErr.Error() + "\n스키마에 맞는 JSON 객체로만 다시 답해줘."},
+		)
 	}
-	return "분석불가", nil
+	return fmt.Errorf("OpenAI 응답 검증 실패: %w", lastErr)
 }
 
-// SummarizeKeywords: 주요 키워드 배열을 받아 인사이트 및 여론 분석 생성 (OpenAI API 활용)
-func SummarizeKeywords(keywords []string) (string, error) {
-	if len(keywords) == 0 {
-		return "주요 키워드가 없습니다.", nil
+// chatCompletion: response_format을 지정해 chat completion 호출, 첫 번째 메시지 내용 반환
+// (OPENAI_CHAT_URL로 엔드포인트 변경 가능)
+func chatCompletion(messages []chatMessage, schemaName string, schema map[string]interface{}) (string, error) {
+	model := currentOpenAIModel()
+	content, status, code, err := chatCompletionWith(model, messages, schemaName, schema)
+	if err != nil && model != fallbackOpenAIModel && isModelError(status, code) {
+		// gpt-4o를 쓸 수 없으면 gpt-3.5-turbo로 재시도
+		primaryModelUnavailable.Store(true)
+		content, _, _, err = chatCompletionWith(fallbackOpenAIModel, messages, schemaName, schema)
 	}
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	prompt := "다음 키워드들을 바탕으로 유튜브 댓글의 주요 인사이트와 여론(주요 토픽, 논쟁점, 긍/부정 분위기 등)을 2~3문장으로 요약해줘.\n- 키워드: " + strings.Join(keywords, ", ") + "\n- 결과는 자연스러운 한글 문장으로, 여론의 전체적 분위기와 논쟁점, 긍/부정/중립 비율 등도 포함해서 요약해줘."
+	return content, err
+}
+
+// isModelError: 모델이 없거나 쓸 수 없다는 4xx 응답인지 (네트워크 오류나 5xx는 대체하지 않음)
+func isModelError(status int, code string) bool {
+	return status == http.StatusNotFound || (status >= 400 && status < 500 && code == "model_not_found")
+}
+
+// chatCompletionWith: 지정한 모델로 호출. 오류면 HTTP 상태와 OpenAI 오류 코드도 반환
+func chatCompletionWith(model string, messages []chatMessage, schemaName string, schema map[string]interface{}) (string, int, string, error) {
 	body := map[string]interface{}{
-		"model": openAIModel,
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
+		"model":           model,
+		"messages":        messages,
+		"response_format": responseFormat(model, schemaName, schema),
 	}
 	jsonBody, _ := json.Marshal(body)
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonBody))
+	url := os.Getenv("OPENAI_CHAT_URL")
+	if url == "" {
+		url = "https://api.openai.com/v1/chat/completions"
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", err
+		return "", 0, "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("OPENAI_API_KEY"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		// gpt-4o 실패시 gpt-3.5-turbo로 재시도
-		if openAIModel != "gpt-3.5-turbo" {
-			openAIModel = "gpt-3.5-turbo"
-			return SummarizeKeywords(keywords)
-		}
-		return "", err
+		return "", 0, "", err
 	}
 	defer resp.Body.Close()
 	var result struct {
@@ -100,12 +216,56 @@ func SummarizeKeywords(keywords []string) (string, error) {
 				Content string `json:"content"`
 			} `json:"message"`
 		} `json:"choices"`
+		Error struct {
+			Message string `json:"message"`
+			Code    string `json:"code"`
+		} `json:"error"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+		return "", resp.StatusCode, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", resp.StatusCode, result.Error.Code, fmt.Errorf("OpenAI API 오류(%d): %s", resp.StatusCode, result.Error.Message)
+	}
+	if len(result.Choices) == 0 {
+		return "", resp.StatusCode, "", fmt.Errorf("OpenAI 응답에 choices가 없음")
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), resp.StatusCode, "", nil
+}
+
+// responseFormat: gpt-3.5-turbo는 json_schema를 지원하지 않으므로 json_object 모드 사용
+func responseFormat(model, schemaName string, schema map[string]interface{}) map[string]interface{} {
+	if model == fallbackOpenAIModel {
+		return map[string]interface{}{"type": "json_object"}
 	}
-	if len(result.Choices) > 0 {
-		return strings.TrimSpace(result.Choices[0].Message.Content), nil
+	return map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name":   schemaName,
+			"strict": true,
+			"schema": schema,
+		},
+	}
+}
+
+// decodeStrict: 알 수 없는 필드나 뒤따르는 데이터가 있으면 실패
+func decodeStrict(content string, out interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("JSON 객체 뒤에 불필요한 데이터가 있음")
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
 	}
-	return "주요 키워드: " + strings.Join(keywords, ", "), nil
+	return false
 }
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeSentimentStrict(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"정상", `{"label":"긍정","confidence":0.9}`, false},
+		{"허용되지 않는 라벨", `{"label":"부정적이지 않고 긍정","confidence":0.5}`, true},
+		{"알 수 없는 필드", `{"label":"중립","confidence":0.5,"reason":"x"}`, true},
+		{"확신도 범위 초과", `{"label":"부정","confidence":1.5}`, true},
+		{"JSON 아님", `긍정`, true},
+		{"뒤따르는 데이터", `{"label":"긍정","confidence":0.9} {}`, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var res SentimentResult
+			err := decodeStrict(tc.content, &res)
+			if err == nil {
+				err = res.validate()
+			}
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// stubChatCompletions: OPENAI_CHAT_URL을 테스트 서버로 바꾸고 replies를 순서대로 응답 (다 쓰면 마지막 응답 반복).
+// 받은 프롬프트(마지막 user 메시지)를 기록하고 요청 횟수를 가리키는 포인터 반환
+func stubChatCompletions(t *testing.T, prompts *[]string, replies ...string) *int {
+	t.Helper()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []chatMessage `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if prompts != nil && len(req.Messages) > 0 {
+			*prompts = append(*prompts, req.Messages[len(req.Messages)-1].Content)
+		}
+		content := replies[len(replies)-1]
+		if calls < len(replies) {
+			content = replies[calls]
+		}
+		calls++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"content": content}}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("OPENAI_CHAT_URL", srv.URL)
+	return &calls
+}
+
+func TestChatJSONReaskClearsStaleFields(t *testing.T) {
+	// 첫 응답은 감정 값이 잘못되어 재요청, 두 번째 응답에는 sarcasm이 없음
+	calls := stubChatCompletions(t, nil,
+		`{"label":"긍정","confidence":0.9,"emotion":"화남","sarcasm":true}`,
+		`{"label":"긍정","confidence":0.8,"emotion":"기쁨"}`,
+	)
+	var res SentimentResult
+	if err := chatJSON("댓글", "emotion", emotionSchema, &res, res.validateEmotion); err != nil {
+		t.Fatalf("재요청 후에도 실패: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("요청 %d번, 기대값 2번", *calls)
+	}
+	if res.Sarcasm {
+		t.Error("이전 응답의 sarcasm 값이 남아 있음")
+	}
+	if res.Emotion != "기쁨" || res.Confidence != 0.8 {
+		t.Errorf("결과 = %+v, 기대값 기쁨/0.8", res)
+	}
+}
+
+func TestAnalyzeSentimentDetailEmotion(t *testing.T) {
+	cases := []struct {
+		name         string
+		reply        string
+		wantEmotion  string
+		wantSarcasm  bool
+		wantEligible bool
+	}{
+		{"기쁨", `{"label":"긍정","confidence":0.9,"emotion":"기쁨","sarcasm":false}`, "기쁨", false, true},
+		{"sarcasm 표시", `{"label":"긍정","confidence":0.7,"emotion":"실망","sarcasm":true}`, "실망", true, false},
+		{"비꼼 감정이면 sarcasm으로 간주", `{"label":"긍정","confidence":0.6,"emotion":"비꼼","sarcasm":false}`, "비꼼", true, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stubChatCompletions(t, nil, tc.reply)
+			res, err := AnalyzeSentimentDetail("댓글", true)
+			if err != nil {
+				t.Fatalf("감정 분석 실패: %v", err)
+			}
+			if res.Emotion != tc.wantEmotion || res.Sarcasm != tc.wantSarcasm || res.Source != SourceOpenAI {
+				t.Errorf("결과 = %+v, 기대값 %s/sarcasm %v", res, tc.wantEmotion, tc.wantSarcasm)
+			}
+			if res.Eligible() != tc.wantEligible {
+				t.Errorf("참가 자격 = %v, 기대값 %v", res.Eligible(), tc.wantEligible)
+			}
+		})
+	}
+}
+
+func TestChatCompletionModelFallback(t *testing.T) {
+	defer primaryModelUnavailable.Store(false)
+	cases := []struct {
+		name         string
+		status       int
+		body         string
+		wantModels   string // 요청한 모델 순서
+		wantErr      bool
+		wantFallback bool
+	}{
+		{"모델 없음(404)이면 대체 모델로 재시도", 404, `{"error":{"message":"no model","code":"model_not_found"}}`, "gpt-4o,gpt-3.5-turbo", false, true},
+		{"model_not_found 400도 대체", 400, `{"error":{"message":"no access","code":"model_not_found"}}`, "gpt-4o,gpt-3.5-turbo", false, true},
+		{"다른 4xx는 대체하지 않음", 401, `{"error":{"message":"bad key","code":"invalid_api_key"}}`, "gpt-4o", true, false},
+		{"5xx는 대체하지 않음", 500, `{"error":{"message":"server"}}`, "gpt-4o", true, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			primaryModelUnavailable.Store(false)
+			var models []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Model string `json:"model"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				models = append(models, req.Model)
+				if req.Model == primaryOpenAIModel {
+					w.WriteHeader(tc.status)
+					w.Write([]byte(tc.body))
+					return
+				}
+				w.Write([]byte(`{"choices":[{"message":{"content":"{}"}}]}`))
+			}))
+			defer srv.Close()
+			t.Setenv("OPENAI_CHAT_URL", srv.URL)
+
+			_, err := chatCompletion(nil, "test", nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, 에러 기대 %v", err, tc.wantErr)
+			}
+			if got := strings.Join(models, ","); got != tc.wantModels {
+				t.Errorf("요청한 모델 %s, 기대값 %s", got, tc.wantModels)
+			}
+			if got := currentOpenAIModel() == fallbackOpenAIModel; got != tc.wantFallback {
+				t.Errorf("대체 모델 사용 = %v, 기대값 %v", got, tc.wantFallback)
+			}
+		})
+	}
+}
+
+func TestChatCompletionTransportErrorKeepsModel(t *testing.T) {
+	defer primaryModelUnavailable.Store(false)
+	primaryModelUnavailable.Store(false)
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close() // 닫힌 서버로 네트워크 오류 발생
+	t.Setenv("OPENAI_CHAT_URL", url)
+	if _, err := chatCompletion(nil, "test", nil); err == nil {
+		t.Fatal("네트워크 오류인데 에러가 없음")
+	}
+	if currentOpenAIModel() != primaryOpenAIModel {
+		t.Error("네트워크 오류로 대체 모델로 바뀜")
+	}
+}
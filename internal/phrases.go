@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// PhraseOptions: 연어(n-gram) 추출 기준
+type PhraseOptions struct {
+	MinFreq int     // 최소 등장 횟수
+	MinPMI  float64 // 최소 PMI (log2)
+}
+
+// DefaultPhraseOptions: 기본 연어 추출 기준 (3회 이상, PMI 3 이상)
+func DefaultPhraseOptions() PhraseOptions {
+	return PhraseOptions{MinFreq: 3, MinPMI: 3}
+}
+
+// 문장 경계 (연어가 문장을 넘어가지 않도록 분리)
+var sentenceSplitRe = regexp.MustCompile(`[.!?,;:\n…~]+`)
+
+// ExtractPhrases: 댓글에서 자주 함께 쓰이는 2~3단어 연어와 빈도 추출.
+// stop에 포함된 단어로 시작하거나 끝나는 연어는 제외
+func ExtractPhrases(comments []string, opt PhraseOptions, stop map[string]bool) map[string]int {
+	uni := map[string]int{}
+	bi := map[[2]string]int{}
+	tri := map[[3]string]int{}
+	total := 0
+	for _, text := range comments {
+		for _, sentence := range sentenceSplitRe.Split(text, -1) {
+			tokens := TokenizeComment(sentence)
+			for i, t := range tokens {
+				uni[t]++
+				total++
+				if i+1 < len(tokens) {
+					bi[[2]string{t, tokens[i+1]}]++
+				}
+				if i+2 < len(tokens) {
+					tri[[3]string{t, tokens[i+1], tokens[i+2]}]++
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return map[string]int{}
+	}
+	n := float64(total)
+	phrases := map[string]int{}
+
+	// 3단어 연어를 먼저 찾고, 그 안에 포함된 2단어 연어 빈도에서 제외
+	biInTri := map[[2]string]int{}
+	for k, c := range tri {
+		if c < opt.MinFreq || stop[k[0]] || stop[k[2]] {
+			continue
+		}
+		pmi := math.Log2(float64(c) * n * n / (float64(uni[k[0]]) * float64(uni[k[1]]) * float64(uni[k[2]])))
+		if pmi < opt.MinPMI {
+			continue
+		}
+		phrases[strings.Join(k[:], " ")] = c
+		biInTri[[2]string{k[0], k[1]}] += c
+		biInTri[[2]string{k[1], k[2]}] += c
+	}
+	for k, c := range bi {
+		c -= biInTri[k]
+		if c < opt.MinFreq || stop[k[0]] || stop[k[1]] {
+			continue
+		}
+		pmi := math.Log2(float64(c) * n / (float64(uni[k[0]]) * float64(uni[k[1]])))
+		if pmi < opt.MinPMI {
+			continue
+		}
+		phrases[k[0]+" "+k[1]] = c
+	}
+	return phrases
+}
+
+// MergePhrases: 단어 빈도 맵에 연어를 추가하고, 연어로 묶인 만큼 구성 단어 빈도를 차감
+func MergePhrases(freq map[string]int, phrases map[string]int) map[string]int {
+	out := make(map[string]int, len(freq)+len(phrases))
+	for w, c := range freq {
+		out[w] = c
+	}
+	for p, c := range phrases {
+		out[p] += c
+		for _, w := range strings.Fields(p) {
+			if _, ok := out[w]; !ok {
+				continue
+			}
+			out[w] -= c
+			if out[w] <= 0 {
+				delete(out, w)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package internal
+
+import "testing"
+
+func TestExtractAndMergePhrases(t *testing.T) {
+	comments := []string{
+		"노래 좋아요. 가사 최고",
+		"노래 좋네요 오늘도 듣고 갑니다",
+		"이 노래 좋다",
+		"first comment!",
+		"First comment lol",
+		"first comment",
+		"편집 최고",
+		"썸네일 보고 왔어요",
+		"오늘 날씨 맑음",
+		"고양이 귀엽네",
+		"배경 음악 정보 있나요",
+		"조명 색감 예쁘다",
+	}
+	phrases := ExtractPhrases(comments, DefaultPhraseOptions(), Stopwords([]string{"ko", "en"}, nil))
+	for _, p := range []string{"노래 좋다", "first comment"} {
+		if phrases[p] != 3 {
+			t.Errorf("phrases[%q] = %d, want 3 (%v)", p, phrases[p], phrases)
+		}
+	}
+	merged := MergePhrases(CountWords(comments), phrases)
+	if merged["노래 좋다"] != 3 {
+		t.Errorf("merged[노래 좋다] = %d, want 3", merged["노래 좋다"])
+	}
+	if _, ok := merged["first"]; ok {
+		t.Errorf("연어로 묶인 단어 first가 남아 있음: %v", merged)
+	}
+}
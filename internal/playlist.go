@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// 재생목록 분석 기본/최대 영상 수
+const (
+	defaultPlaylistVideos = 10
+	maxPlaylistVideos     = 25
+)
+
+// PlaylistInfo: 재생목록 제목과 채널
+type PlaylistInfo struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Channel string `json:"channel"`
+}
+
+// PlaylistAnalysis: 재생목록 영상들의 댓글 분석 결과 (영상별 요약으로 개별 결과 조회)
+type PlaylistAnalysis struct {
+	Playlist PlaylistInfo `json:"playlist"`
+	*MultiVideoAnalysis
+	Results []*AnalysisResult `json:"-"` // 집계에 쓴 영상별 분석 결과 (영상별 기록 저장용)
+}
+
+// FetchPlaylistInfo: 재생목록 ID로 제목, 채널명 조회
+func FetchPlaylistInfo(playlistID string) (PlaylistInfo, error) {
+	apiKey := os.Getenv("YOUTUBE_API_KEY")
+	url := fmt.Sprintf("https://www.googleapis.com/youtube/v3/playlists?part=snippet&id=%s&key=%s", playlistID, apiKey)
+	resp, err := http.Get(url)
+	if err != nil {
+		return PlaylistInfo{}, err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Items []struct {
+			Snippet struct {
+				Title        string `json:"title"`
+				ChannelTitle string `json:"channelTitle"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return PlaylistInfo{}, err
+	}
+	if len(result.Items) == 0 {
+		return PlaylistInfo{}, fmt.Errorf("재생목록을 찾을 수 없음")
+	}
+	return PlaylistInfo{ID: playlistID, Title: result.Items[0].Snippet.Title, Channel: result.Items[0].Snippet.ChannelTitle}, nil
+}
+
+// playlistRouteID: 입력값이 재생목록 자체를 가리킬 때만 재생목록 ID 반환.
+// 재생목록에서 연 영상 링크(watch?v=X&list=PL...)는 영상 분석으로 보내야 하므로 빈 문자열
+func playlistRouteID(input string) string {
+	ref, err := ParseYouTubeRef(input)
+	if err != nil || ref.Kind != RefPlaylist {
+		return ""
+	}
+	return ParsePlaylistID(input)
+}
+
+// AnalyzePlaylist: 재생목록 앞쪽 영상 n개를 분석해 영상별/전체 감성과 키워드 집계 (캐시와 동시 실행 한도 공유)
+func AnalyzePlaylist(ctx context.Context, playlistID string, n int, opt AnalysisOptions) (*PlaylistAnalysis, error) {
+	if n <= 0 {
+		n = defaultPlaylistVideos
+	}
+	if n > maxPlaylistVideos {
+		n = maxPlaylistVideos
+	}
+	info, err := FetchPlaylistInfo(playlistID)
+	if err != nil {
+		return nil, err
+	}
+	videoIDs, err := FetchPlaylistVideoIDs(playlistID, n)
+	if err != nil {
+		return nil, err
+	}
+	results, failed := AnalyzeVideos(ctx, videoIDs, opt)
+	if len(results) == 0 {
+		return nil, fmt.Errorf("분석할 수 있는 영상이 없음")
+	}
+	agg := AggregateAnalyses(results)
+	agg.FailedVideoIDs = failed
+	return &PlaylistAnalysis{Playlist: info, MultiVideoAnalysis: agg, Results: results}, nil
+}
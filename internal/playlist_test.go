@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPlaylistIDParsing(t *testing.T) {
+	const (
+		vid  = "dQw4w9WgXcQ"
+		list = "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf"
+		mix  = "RDdQw4w9WgXcQ"
+	)
+	cases := []struct {
+		name      string
+		input     string
+		playlist  string // ParsePlaylistID 결과
+		routeList string // playlistRouteID 결과 (재생목록 분석으로 보낼지)
+	}{
+		{"재생목록 URL", "https://www.youtube.com/playlist?list=" + list, list, list},
+		{"재생목록 ID", list, list, list},
+		{"v 없는 watch 링크", "https://www.youtube.com/watch?list=" + list, list, list},
+		{"재생목록에서 연 영상", "https://www.youtube.com/watch?v=" + vid + "&list=" + list + "&index=2", list, ""},
+		{"재생목록에서 연 짧은 링크", "https://youtu.be/" + vid + "?list=" + list, list, ""},
+		{"자동 생성 믹스", "https://www.youtube.com/watch?v=" + vid + "&list=" + mix, "", ""},
+		{"믹스 재생목록 URL", "https://www.youtube.com/playlist?list=" + mix, "", ""},
+		{"일반 영상", "https://www.youtube.com/watch?v=" + vid, "", ""},
+		{"채널", "https://www.youtube.com/@GoogleDevelopers", "", ""},
+		{"잘못된 입력", "hello world", "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ParsePlaylistID(tc.input); got != tc.playlist {
+				t.Errorf("ParsePlaylistID(%q) = %q, 기대값 %q", tc.input, got, tc.playlist)
+			}
+			if got := playlistRouteID(tc.input); got != tc.routeList {
+				t.Errorf("playlistRouteID(%q) = %q, 기대값 %q", tc.input, got, tc.routeList)
+			}
+		})
+	}
+}
+
+func TestAnalyzeHandlerRequiresPost(t *testing.T) {
+	// 링크 미리보기/크롤러의 GET으로 분석이 실행되면 안 됨
+	for _, target := range []string{
+		"/analyze?video_id=dQw4w9WgXcQ",
+		"/analyze?video_id=https://www.youtube.com/playlist?list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf",
+	} {
+		rec := httptest.NewRecorder()
+		AnalyzeHandler(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusSeeOther {
+			t.Errorf("GET %s 응답 코드 %d, 기대값 %d", target, rec.Code, http.StatusSeeOther)
+		}
+	}
+}
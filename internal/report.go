@@ -0,0 +1,252 @@
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-echarts/go-echarts/v2/render"
+)
+
+// 리포트 모드 (/report?mode=)
+const (
+	ReportInteractive = "interactive" // echarts 스크립트를 파일에 내장 (스크립트가 없으면 SVG로 대체)
+	ReportStatic      = "static"      // 스크립트 없이 SVG 차트만 사용
+)
+
+// 리포트에 담을 공감 댓글 수, 썸네일 최대 크기, 메모리에 보관하는 썸네일 수
+const (
+	reportTopComments   = 3
+	maxThumbnailBytes   = 1 << 20
+	maxCachedThumbnails = 256
+)
+
+var (
+	// 썸네일 URL별 data URI (실패도 빈 값으로 보관). 공개 /report 요청마다 외부로 요청하지 않도록 한 번만 가져옴
+	thumbnailCacheMu sync.Mutex
+	thumbnailCache   = map[string]template.URL{}
+	// echarts 스크립트가 없어 SVG로 대체한다는 로그는 한 번만 남김
+	echartsMissingOnce sync.Once
+)
+
+// reportChart: 리포트의 차트 하나 (Element/Script가 있으면 echarts, 없으면 SVG)
+type reportChart struct {
+	Title   string
+	SVG     template.HTML
+	Element template.HTML
+	Script  template.HTML
+}
+
+// snippetRenderer: 차트를 다른 HTML에 끼워 넣을 조각으로 렌더링 (go-echarts 차트 공통)
+type snippetRenderer interface {
+	RenderSnippet() render.ChartSnippet
+}
+
+// GenerateReport: 분석 결과를 외부 파일 없이 열리는 단일 HTML 리포트로 출력
+// (CSS와 차트 스크립트, 썸네일을 모두 파일에 포함)
+func GenerateReport(w io.Writer, res *AnalysisResult, mode string) error {
+	var echartsJS, wordcloudJS string
+	if mode != ReportStatic {
+		echartsJS, wordcloudJS = loadEchartsAssets()
+	}
+	interactive := echartsJS != ""
+
+	chart := func(title string, c snippetRenderer, svg string) reportChart {
+		if !interactive || c == nil {
+			return reportChart{Title: title, SVG: template.HTML(svg)}
+		}
+		s := c.RenderSnippet()
+		return reportChart{Title: title, Element: template.HTML(s.Element), Script: template.HTML(s.Script)}
+	}
+	chartList := []reportChart{
+		chart("감성 비율", labelPie("감성분석", res.Labels), SentimentPieSVG(res.Shares, 200)),
+		chart("감성 비율과 95% 신뢰구간", nil, SentimentBarsSVG(res.Shares, 560)),
+	}
+	var cloud snippetRenderer
+	if wordcloudJS != "" {
+		cloud = wordCloud(res.WordFreq)
+	}
+	chartList = append(chartList, chart("워드클라우드", cloud, WordCloudSVG(res.WordFreq, 640, 400)))
+	if res.Emotions != nil {
+		chartList = append(chartList, chart("감정 분포", labelPie("감정분석", res.EmotionLabels()), LabelPieSVG("감정분석", res.EmotionLabels(), 200)))
+	}
+	if len(res.Timeline.Buckets) > 0 {
+		chartList = append(chartList, chart("업로드 후 감성 변화", timelineChart(res.Timeline), TimelineSVG(res.Timeline, 640, 300)))
+	}
+
+	scripts := []template.JS{}
+	if interactive {
+		scripts = append(scripts, template.JS(echartsJS))
+		if wordcloudJS != "" {
+			scripts = append(scripts, template.JS(wordcloudJS))
+		}
+	}
+	engaged := map[string][]EngagedComment{}
+	for label, list := range res.TopEngaged {
+		if len(list) > reportTopComments {
+			list = list[:reportTopComments]
+		}
+		engaged[label] = list
+	}
+	return reportTemplate.Execute(w, map[string]interface{}{
+		"CSS":         template.CSS(reportCSS),
+		"Scripts":     scripts,
+		"Charts":      chartList,
+		"VideoID":     res.VideoID,
+		"Meta":        res.Meta,
+		"Thumbnail":   cachedThumbnailDataURI(res.Meta.Thumbnail),
+		"Shares":      res.Shares,
+		"TotalCount":  res.TotalCount,
+		"Sampling":    res.Sampling,
+		"Insight":     res.Insight,
+		"TopKeywords": res.TopKeywords,
+		"TopEngaged":  engaged,
+		"GeneratedAt": time.Now().Format("2006-01-02 15:04"),
+	})
+}
+
+// loadEchartsAssets: 리포트에 내장할 echarts 스크립트를 읽음
+// (ECHARTS_ASSETS_DIR, 기본 web/static/assets. 저장소에는 없고 Docker 이미지 빌드시 받음.
+// 파일이 없으면 빈 문자열이고 interactive 리포트는 SVG 차트로 대체)
+func loadEchartsAssets() (echartsJS, wordcloudJS string) {
+	dir := os.Getenv("ECHARTS_ASSETS_DIR")
+	if dir == "" {
+		dir = "web/static/assets"
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "echarts.min.js"))
+	if err != nil {
+		echartsMissingOnce.Do(func() {
+			log.Printf("echarts 스크립트를 읽지 못해 interactive 리포트를 SVG 차트로 대체: %v", err)
+		})
+		return "", ""
+	}
+	echartsJS = string(b)
+	if b, err := os.ReadFile(filepath.Join(dir, "echarts-wordcloud.min.js")); err == nil {
+		wordcloudJS = string(b)
+	}
+	return
+}
+
+// cachedThumbnailDataURI: 썸네일 URL마다 한 번만 가져와 data URI로 보관 (가득 차면 임의의 항목 하나를 비움)
+func cachedThumbnailDataURI(url string) template.URL {
+	if url == "" {
+		return ""
+	}
+	thumbnailCacheMu.Lock()
+	uri, ok := thumbnailCache[url]
+	thumbnailCacheMu.Unlock()
+	if ok {
+		return uri
+	}
+	uri = thumbnailDataURI(url)
+	thumbnailCacheMu.Lock()
+	if len(thumbnailCache) >= maxCachedThumbnails {
+		for k := range thumbnailCache {
+			delete(thumbnailCache, k)
+			break
+		}
+	}
+	thumbnailCache[url] = uri
+	thumbnailCacheMu.Unlock()
+	return uri
+}
+
+// thumbnailDataURI: 썸네일 이미지를 data URI로 변환 (실패하면 빈 값, 리포트에서 생략)
+func thumbnailDataURI(url string) template.URL {
+	if url == "" {
+		return ""
+	}
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	contentType := resp.Header.Get("Content-Type")
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(contentType, "image/") {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxThumbnailBytes+1))
+	if err != nil || len(body) > maxThumbnailBytes {
+		return ""
+	}
+	return template.URL(fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(body)))
+}
+
+const reportCSS = `
+body { margin: 0; padding: 24px; background: #f5f6f8; color: #222; font-family: 'Malgun Gothic', 'Apple SD Gothic Neo', 'Noto Sans KR', sans-serif; }
+main { max-width: 880px; margin: 0 auto; }
+section { background: #fff; border-radius: 8px; padding: 16px 20px; margin-bottom: 16px; box-shadow: 0 1px 3px rgba(0,0,0,.08); }
+h1 { font-size: 22px; margin: 0 0 4px; }
+h2 { font-size: 17px; margin: 0 0 12px; }
+.header { display: flex; gap: 16px; align-items: center; }
+.header img { width: 240px; border-radius: 6px; }
+.muted { color: #777; font-size: 13px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #eee; padding: 6px 8px; text-align: left; font-size: 14px; }
+.chart { margin: 8px 0 16px; overflow-x: auto; }
+.keywords span { display: inline-block; background: #eef3fa; border-radius: 12px; padding: 2px 10px; margin: 2px; font-size: 13px; }
+blockquote { margin: 6px 0; padding: 4px 12px; border-left: 3px solid #ccc; font-size: 14px; }
+`
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Meta.Title}} 댓글 분석 리포트</title>
+<style>{{.CSS}}</style>
+{{range .Scripts}}<script>{{.}}</script>
+{{end}}</head>
+<body>
+<main>
+<section class="header">
+{{if .Thumbnail}}<img src="{{.Thumbnail}}" alt="썸네일">{{end}}
+<div>
+<h1>{{if .Meta.Title}}{{.Meta.Title}}{{else}}{{.VideoID}}{{end}}</h1>
+<div class="muted">{{.Meta.Channel}} · https://www.youtube.com/watch?v={{.VideoID}}</div>
+<div class="muted">분석한 댓글 {{.TotalCount}}개{{if .Sampling.AvailableCount}} / 전체 {{.Sampling.AvailableCount}}개{{end}} · {{.GeneratedAt}} 생성</div>
+</div>
+</section>
+<section>
+<h2>감성 분포</h2>
+<table>
+<tr><th>감성</th><th>댓글 수</th><th>비율</th><th>95% 신뢰구간</th></tr>
+{{range .Shares}}<tr><td>{{.Label}}</td><td>{{.Count}}</td><td>{{.Percent}}%</td><td>{{printf "%.1f" .Lower}}% ~ {{printf "%.1f" .Upper}}%</td></tr>
+{{end}}</table>
+</section>
+<section>
+<h2>차트</h2>
+{{range .Charts}}<div class="chart">
+<div class="muted">{{.Title}}</div>
+{{if .Element}}{{.Element}}{{.Script}}{{else}}{{.SVG}}{{end}}
+</div>
+{{end}}</section>
+{{with .Insight}}<section>
+<h2>인사이트</h2>
+<p>{{.OverallMood}}</p>
+{{if .MainTopics}}<p>주요 토픽: {{range $i, $t := .MainTopics}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}
+{{if .Controversies}}<p>논쟁점: {{range $i, $t := .Controversies}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}
+{{range .NotableQuotes}}<blockquote>{{.Text}} <span class="muted">— {{.Author}}{{if .Reason}} ({{.Reason}}){{end}}</span></blockquote>
+{{end}}</section>{{end}}
+{{if .TopKeywords}}<section class="keywords">
+<h2>주요 키워드</h2>
+{{range .TopKeywords}}<span>{{.}}</span>{{end}}
+</section>{{end}}
+{{if .TopEngaged}}<section>
+<h2>공감을 많이 받은 댓글</h2>
+{{range $label, $list := .TopEngaged}}{{if $list}}<h3>{{$label}}</h3>
+{{range $list}}<blockquote>{{.Text}} <span class="muted">— {{.Author}} · 좋아요 {{.LikeCount}} · 답글 {{.ReplyCount}}</span></blockquote>
+{{end}}{{end}}{{end}}</section>{{end}}
+</main>
+</body>
+</html>
+`))
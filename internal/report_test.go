@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateReportStatic(t *testing.T) {
+	res := exportFixture()
+	res.Meta.Title = "<제목>"
+	res.Labels = []string{"긍정", "부정"}
+	var buf bytes.Buffer
+	if err := GenerateReport(&buf, res, ReportStatic); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script") {
+		t.Error("정적 리포트에 스크립트가 들어 있음")
+	}
+	if strings.Contains(out, `src="http`) {
+		t.Error("정적 리포트가 외부 리소스를 불러옴")
+	}
+	if !strings.Contains(out, "&lt;제목&gt;") {
+		t.Error("제목이 이스케이프되지 않음")
+	}
+	if n := strings.Count(out, "<svg"); n < 3 {
+		t.Errorf("SVG 차트 %d개, 기대값 3개 이상", n)
+	}
+}
+
+func TestGenerateReportInteractive(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "echarts.min.js"), []byte("/*echarts-test*/"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ECHARTS_ASSETS_DIR", dir)
+	res := exportFixture()
+	res.Labels = []string{"긍정", "부정"}
+	var buf bytes.Buffer
+	if err := GenerateReport(&buf, res, ReportInteractive); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "/*echarts-test*/") || !strings.Contains(out, "echarts.init") {
+		t.Error("인터랙티브 리포트에 내장 echarts 스크립트가 없음")
+	}
+	// 워드클라우드 확장 스크립트가 없으면 SVG로 대체
+	if !strings.Contains(out, "워드클라우드") || !strings.Contains(out, "<svg") {
+		t.Error("워드클라우드가 SVG로 대체되지 않음")
+	}
+}
+
+func TestReportThumbnailFetchedOnce(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	}))
+	defer srv.Close()
+	res := exportFixture()
+	res.Meta.Thumbnail = srv.URL + "/thumb.png"
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		if err := GenerateReport(&buf, res, ReportStatic); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "data:image/png;base64,") {
+			t.Fatal("썸네일이 data URI로 들어가지 않음")
+		}
+	}
+	if hits != 1 {
+		t.Errorf("썸네일 요청 %d번, 기대값 1번", hits)
+	}
+}
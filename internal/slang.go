@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"math"
+	"regexp"
+	"sort"
+)
+
+// 오프라인 감성 점수가 이 값 이상이면 긍정, -값 이하면 부정
+const offlineSentimentThreshold = 0.5
+
+// Signal: 댓글에서 찾은 이모지/인터넷 신조어와 감성 점수
+type Signal struct {
+	Kind  string  `json:"kind"` // "emoji" 또는 "slang"
+	Token string  `json:"token"`
+	Score float64 `json:"score"`
+}
+
+// SignalCount: 이모지/신조어 등장 통계
+type SignalCount struct {
+	Token string  `json:"token"`
+	Count int     `json:"count"`
+	Score float64 `json:"score"`
+}
+
+// 이모지 감성 사전 (변형 선택자/피부색은 NormalizeEmoji로 제거한 형태)
+var emojiLexicon = map[rune]float64{
+	'😍': 1.5, '🥰': 1.5, '😘': 1, '❤': 1.5, '💕': 1.5, '💖': 1.5, '💗': 1.5, '💙': 1, '💜': 1, '🧡': 1, '💛': 1,
+	'👍': 1, '👏': 1, '🙌': 1, '🔥': 1, '✨': 0.5, '🎉': 1, '😊': 1, '😄': 1, '😁': 1, '😆': 1, '🤗': 1, '🙏': 1,
+	'😂': 0.5, '🤣': 0.5, '😭': 0.2, '🥹': 0.5, '💯': 1, '👑': 1,
+	'😡': -1.5, '🤬': -2, '😠': -1.5, '👎': -1.5, '💩': -1.5, '😒': -1, '🙄': -1, '😤': -1, '😢': -0.5,
+	'😞': -1, '😩': -1, '🤮': -2, '🤢': -1.5, '😑': -0.5, '😐': -0.3,
+}
+
+// 인터넷 신조어 감성 사전 (SquashRepeatedChars와 무관하게 반복 횟수는 정규식으로 허용)
+var slangLexicon = []struct {
+	name  string
+	re    *regexp.Regexp
+	score float64
+}{
+	{"ㅋㅋ", regexp.MustCompile(`ㅋ{2,}`), 0.5},
+	{"ㅎㅎ", regexp.MustCompile(`ㅎ{2,}`), 0.5},
+	{"ㅠㅠ", regexp.MustCompile(`[ㅠㅜ]{2,}`), -0.2},
+	{"ㄹㅇ", regexp.MustCompile(`ㄹㅇ`), 0},
+	{"ㅇㅈ", regexp.MustCompile(`ㅇㅈ`), 0.5},
+	{"ㄱㅇㄷ", regexp.MustCompile(`ㄱㅇㄷ`), 1},
+	{"ㄷㄷ", regexp.MustCompile(`ㄷ{2,}`), 0.3},
+	{"ㅡㅡ", regexp.MustCompile(`ㅡ{2,}`), -1},
+	{"ㅗ", regexp.MustCompile(`(^|[^ㅗ\p{L}])ㅗ+($|[^ㅗ\p{L}])`), -2},
+	{"개좋다", regexp.MustCompile(`개(좋|웃기|웃겨|웃김|쩔|쩐|맛있|잘|귀엽|귀여|멋있|멋지|이득)`), 1.5},
+	{"존잼", regexp.MustCompile(`(존|꿀|개)잼`), 1.5},
+	{"노잼", regexp.MustCompile(`(핵|개)?노잼`), -1.5},
+	{"레전드", regexp.MustCompile(`레전드|전설`), 1.5},
+	{"갓", regexp.MustCompile(`갓[\p{Hangul}]`), 1},
+	{"찢었다", regexp.MustCompile(`찢었|찢어|찢음`), 1.5},
+	{"미쳤다", regexp.MustCompile(`미쳤|미친(다|듯)`), 1},
+	{"킹받다", regexp.MustCompile(`킹받`), -1},
+	{"극혐", regexp.MustCompile(`극혐`), -2},
+	{"에바", regexp.MustCompile(`에바(다|네|임|야)?`), -1},
+	{"실화냐", regexp.MustCompile(`실화(냐|임)?`), 0.5},
+	{"최애", regexp.MustCompile(`최애`), 1.5},
+	{"입덕", regexp.MustCompile(`입덕`), 1.5},
+	{"탈덕", regexp.MustCompile(`탈덕`), -1.5},
+}
+
+// 오프라인 감성 점수용 단어 사전 (KoreanTokenizer가 정규화한 형태)
+var offlineWordLexicon = map[string]float64{
+	"좋다": 1, "최고": 1.5, "감사하다": 1, "감사": 1, "고맙다": 1, "사랑하다": 1.5, "사랑": 1, "재밌다": 1,
+	"재미있다": 1, "행복하다": 1, "멋있다": 1, "멋지다": 1, "예쁘다": 1, "귀엽다": 1, "대박": 1, "응원하다": 1,
+	"응원": 1, "힐링": 1, "감동": 1, "추천": 0.5, "love": 1.5, "best": 1, "great": 1, "amazing": 1.5,
+	"awesome": 1.5, "good": 1, "thanks": 1, "thank": 1, "beautiful": 1, "cute": 1,
+	"싫다": -1, "별로": -1, "최악": -2, "실망": -1.5, "짜증": -1.5, "재미없다": -1.5, "아쉽다": -0.5,
+	"불편하다": -1, "역겹다": -2, "쓰레기": -2, "구독취소": -1.5, "hate": -1.5, "worst": -2, "bad": -1,
+	"boring": -1, "terrible": -2, "awful": -2, "disappointed": -1.5,
+}
+
+// ExtractSignals: 댓글에서 이모지와 인터넷 신조어 신호 추출
+func ExtractSignals(text string) []Signal {
+	var signals []Signal
+	for _, r := range NormalizeEmoji(text) {
+		if !isEmoji(r) {
+			continue
+		}
+		signals = append(signals, Signal{Kind: "emoji", Token: string(r), Score: emojiLexicon[r]})
+	}
+	for _, s := range slangLexicon {
+		for range s.re.FindAllString(text, -1) {
+			signals = append(signals, Signal{Kind: "slang", Token: s.name, Score: s.score})
+		}
+	}
+	return signals
+}
+
+// ScoreSentimentOffline: OpenAI 없이 이모지/신조어/단어 사전으로 감성 점수 계산
+func ScoreSentimentOffline(text string) SentimentResult {
+	score := 0.0
+	for _, s := range ExtractSignals(text) {
+		score += s.Score
+	}
+	for _, w := range (KoreanTokenizer{}).Tokenize(text) {
+		score += offlineWordLexicon[w]
+	}
+	label := "중립"
+	if score >= offlineSentimentThreshold {
+		label = "긍정"
+	} else if score <= -offlineSentimentThreshold {
+		label = "부정"
+	}
+	return SentimentResult{Label: label, Confidence: math.Min(1, 0.5+math.Abs(score)/4), Source: SourceOffline}
+}
+
+// SignalStats: 댓글 전체의 이모지/신조어 빈도 통계 (많은 순)
+func SignalStats(comments []Comment) (emojis []SignalCount, slang []SignalCount) {
+	emojiCount := map[string]*SignalCount{}
+	slangCount := map[string]*SignalCount{}
+	for _, c := range comments {
+		for _, s := range ExtractSignals(c.Text) {
+			m := emojiCount
+			if s.Kind == "slang" {
+				m = slangCount
+			}
+			if sc, ok := m[s.Token]; ok {
+				sc.Count++
+			} else {
+				m[s.Token] = &SignalCount{Token: s.Token, Count: 1, Score: s.Score}
+			}
+		}
+	}
+	return sortedSignalCounts(emojiCount), sortedSignalCounts(slangCount)
+}
+
+func sortedSignalCounts(m map[string]*SignalCount) []SignalCount {
+	out := make([]SignalCount, 0, len(m))
+	for _, sc := range m {
+		out = append(out, *sc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Token < out[j].Token
+	})
+	return out
+}
+
+// isEmoji: 그림 이모지 범위의 문자인지
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F300 && r <= 0x1FAFF: // 기호/그림문자, 이모티콘, 추가 그림문자
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // 기타 기호, 딩뱃
+		return true
+	case r == 0x2B50 || r == 0x2B55: // ⭐ ⭕
+		return true
+	}
+	return false
+}
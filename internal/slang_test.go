@@ -0,0 +1,47 @@
+package internal
+
+import "testing"
+
+func TestScoreSentimentOffline(t *testing.T) {
+	cases := map[string]string{
+		"개좋아 ㅋㅋㅋ 😍😍": "긍정",
+		"이번 편 레전드👍🏻": "긍정",
+		"핵노잼 ㅡㅡ":     "부정",
+		"최악이네요 👎":    "부정",
+		"오늘 8시에 올라옴": "중립",
+	}
+	for text, want := range cases {
+		got := ScoreSentimentOffline(text)
+		if got.Label != want {
+			t.Errorf("ScoreSentimentOffline(%q) = %q, want %q", text, got.Label, want)
+		}
+		if got.Source != SourceOffline {
+			t.Errorf("ScoreSentimentOffline(%q) 출처 = %q", text, got.Source)
+		}
+	}
+}
+
+func TestCountSentimentSource(t *testing.T) {
+	sentiments := []SentimentResult{
+		{Label: "긍정", Source: SourceOpenAI},
+		ScoreSentimentOffline("핵노잼"),
+		{Label: "중립", Source: SourceOpenAI},
+		ScoreSentimentOffline("개좋아"),
+	}
+	if got := countSentimentSource(sentiments, SourceOffline); got != 2 {
+		t.Errorf("사전 기반 대체 댓글 수 = %d, 기대값 2", got)
+	}
+}
+
+func TestSignalStats(t *testing.T) {
+	emojis, slang := SignalStats([]Comment{
+		{Text: "❤️❤ ㅋㅋㅋ"},
+		{Text: "❤ ㄹㅇ ㅋㅋ"},
+	})
+	if len(emojis) != 1 || emojis[0].Token != "❤" || emojis[0].Count != 3 {
+		t.Errorf("이모지 통계 = %+v", emojis)
+	}
+	if len(slang) != 2 || slang[0].Token != "ㅋㅋ" || slang[0].Count != 2 {
+		t.Errorf("신조어 통계 = %+v", slang)
+	}
+}
@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"math"
+	"sort"
+)
+
+// 95% 신뢰수준의 z값
+const z95 = 1.959964
+
+// SamplingInfo: 분석에 사용한 댓글 표본 정보
+type SamplingInfo struct {
+	AvailableCount int     `json:"availableCount"` // 영상의 전체 댓글 수 (YouTube commentCount)
+	FetchedCount   int     `json:"fetchedCount"`   // 수집한 댓글 수
+	AnalyzedCount  int     `json:"analyzedCount"`  // 필터링/샘플링 후 분석한 댓글 수
+	SamplingRate   float64 `json:"samplingRate"`   // 분석한 댓글 / 전체 댓글 (0~1)
+}
+
+// ShareEstimate: 감성 비율과 95% 신뢰구간 (Lower/Upper는 % 단위)
+type ShareEstimate struct {
+	Label   string  `json:"label"`
+	Count   int     `json:"count"`
+	Percent int     `json:"percent"` // 합이 100이 되도록 최대 잔여 방식으로 반올림
+	Lower   float64 `json:"lower"`
+	Upper   float64 `json:"upper"`
+}
+
+// NewSamplingInfo: 표본 정보 계산 (전체 댓글 수를 모르면 수집한 댓글 수 기준)
+func NewSamplingInfo(available, fetched, analyzed int) SamplingInfo {
+	if available < fetched {
+		available = fetched
+	}
+	info := SamplingInfo{AvailableCount: available, FetchedCount: fetched, AnalyzedCount: analyzed}
+	if available > 0 {
+		info.SamplingRate = float64(analyzed) / float64(available)
+	}
+	return info
+}
+
+// WilsonInterval: k/n 비율의 Wilson 점수 신뢰구간.
+// population이 n보다 크면 유한 모집단 보정을 적용 (전수 분석이면 구간 폭 0)
+func WilsonInterval(k, n, population int, z float64) (float64, float64) {
+	if n == 0 {
+		return 0, 0
+	}
+	p := float64(k) / float64(n)
+	if population > 0 && n >= population {
+		return p, p
+	}
+	nEff := float64(n)
+	if population > n && population > 1 {
+		nEff = float64(n) * float64(population-1) / float64(population-n)
+	}
+	z2 := z * z
+	denom := 1 + z2/nEff
+	center := (p + z2/(2*nEff)) / denom
+	margin := z * math.Sqrt(p*(1-p)/nEff+z2/(4*nEff*nEff)) / denom
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// LargestRemainderPercents: 개수를 백분율로 바꾸되 합이 정확히 100이 되도록 최대 잔여 방식으로 반올림
+func LargestRemainderPercents(counts []int) []int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	percents := make([]int, len(counts))
+	if total == 0 {
+		return percents
+	}
+	type rem struct {
+		idx  int
+		frac float64
+	}
+	rems := make([]rem, len(counts))
+	sum := 0
+	for i, c := range counts {
+		exact := float64(c) * 100 / float64(total)
+		percents[i] = int(math.Floor(exact))
+		sum += percents[i]
+		rems[i] = rem{i, exact - math.Floor(exact)}
+	}
+	sort.SliceStable(rems, func(a, b int) bool { return rems[a].frac > rems[b].frac })
+	for i := 0; sum < 100; i++ {
+		percents[rems[i%len(rems)].idx]++
+		sum++
+	}
+	return percents
+}
+
+// SentimentShares: 긍정/부정/중립 비율, 반올림된 %, Wilson 신뢰구간 계산
+func SentimentShares(pos, neg, neu, population int) []ShareEstimate {
+	counts := []int{pos, neg, neu}
+	n := pos + neg + neu
+	percents := LargestRemainderPercents(counts)
+	shares := make([]ShareEstimate, len(counts))
+	for i, label := range sentimentLabels {
+		lo, hi := WilsonInterval(counts[i], n, population, z95)
+		shares[i] = ShareEstimate{
+			Label:   label,
+			Count:   counts[i],
+			Percent: percents[i],
+			Lower:   math.Round(lo*1000) / 10,
+			Upper:   math.Round(hi*1000) / 10,
+		}
+	}
+	return shares
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLargestRemainderPercents(t *testing.T) {
+	cases := []struct {
+		counts []int
+		want   []int
+	}{
+		{[]int{1, 1, 1}, []int{34, 33, 33}},
+		{[]int{2, 1, 0}, []int{67, 33, 0}},
+		{[]int{57, 28, 15}, []int{57, 28, 15}},
+		{[]int{0, 0, 0}, []int{0, 0, 0}},
+	}
+	for _, tc := range cases {
+		got := LargestRemainderPercents(tc.counts)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("LargestRemainderPercents(%v) = %v, want %v", tc.counts, got, tc.want)
+		}
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	lo, hi := WilsonInterval(50, 100, 0, z95)
+	if math.Abs(lo-0.4038) > 0.001 || math.Abs(hi-0.5962) > 0.001 {
+		t.Errorf("WilsonInterval(50, 100) = (%.4f, %.4f)", lo, hi)
+	}
+	// 유한 모집단 보정: 모집단이 작을수록 구간이 좁아짐
+	flo, fhi := WilsonInterval(50, 100, 300, z95)
+	if fhi-flo >= hi-lo {
+		t.Errorf("유한 모집단 보정 구간이 더 넓음: (%.4f, %.4f)", flo, fhi)
+	}
+	// 전수 분석이면 구간 폭 0
+	if lo, hi := WilsonInterval(30, 100, 100, z95); lo != 0.3 || hi != 0.3 {
+		t.Errorf("전수 분석 구간 = (%v, %v)", lo, hi)
+	}
+}
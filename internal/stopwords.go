@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// 언어별 기본 불용어 (토크나이저가 정규화한 형태 기준)
+var builtinStopwords = map[string][]string{
+	"ko": {
+		"진짜", "너무", "정말", "완전", "그냥", "근데", "그리고", "그래서", "그런데", "하지만", "또한",
+		"이거", "저거", "그거", "이건", "저건", "그건", "이게", "그게", "여기", "거기", "저기",
+		"이런", "그런", "저런", "이렇게", "그렇게", "저렇게", "어떻게", "우리", "저희", "나는", "제가", "내가",
+		"하다", "되다", "있다", "없다", "같다", "보다", "이다", "아니다", "않다", "것", "때문", "정도",
+		"영상", "ㅋㅋ", "ㅎㅎ", "ㅠㅠ", "ㅜㅜ",
+	},
+	"en": {
+		"the", "and", "a", "an", "is", "are", "was", "were", "be", "been", "to", "of", "in", "on", "for",
+		"it", "its", "it's", "this", "that", "with", "as", "at", "by", "from", "or", "but", "not", "so",
+		"i", "i'm", "you", "he", "she", "we", "they", "me", "my", "your", "our", "his", "her", "their",
+		"do", "does", "did", "have", "has", "had", "just", "like", "can", "will", "what", "who", "all",
+		"very", "really", "too", "there", "here", "when", "how", "if", "than", "then", "about", "video",
+	},
+	"es": {"el", "la", "los", "las", "que", "de", "del", "y", "es", "en", "un", "una", "por", "con", "para", "lo", "se", "mi", "muy"},
+	"pt": {"o", "os", "as", "que", "de", "do", "da", "e", "é", "em", "um", "uma", "com", "para", "não", "eu", "muito", "isso"},
+	"fr": {"le", "la", "les", "et", "est", "je", "tu", "que", "une", "un", "des", "pour", "pas", "de", "du", "en", "c'est", "très"},
+	"de": {"der", "die", "das", "und", "ist", "ich", "nicht", "ein", "eine", "sehr", "du", "mit", "auf", "für", "es", "zu"},
+	"id": {"yang", "dan", "ini", "itu", "aku", "kamu", "tidak", "dari", "untuk", "di", "ke", "ada", "sih", "ya"},
+}
+
+// Stopwords: 언어별 기본 불용어 + 추가 불용어 집합
+func Stopwords(langs []string, extra []string) map[string]bool {
+	stop := map[string]bool{}
+	for _, lang := range langs {
+		for _, w := range builtinStopwords[lang] {
+			stop[w] = true
+		}
+	}
+	for _, w := range extra {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w != "" {
+			stop[w] = true
+		}
+	}
+	return stop
+}
+
+// CountWordsByLanguage: 댓글마다 감지된 언어의 기본 불용어와 extra를 빼고 단어 빈도 계산.
+// 영어 불용어("no" 등)가 한국어 댓글의 단어를 지우지 않도록 언어별로 적용 (언어를 모르면 ko, en 목록)
+func CountWordsByLanguage(comments []Comment, extra []string) map[string]int {
+	freq := map[string]int{}
+	stops := map[string]map[string]bool{}
+	for _, c := range comments {
+		stop, ok := stops[c.Language]
+		if !ok {
+			langs := []string{c.Language}
+			if c.Language == "" {
+				langs = []string{"ko", "en"}
+			}
+			stop = Stopwords(langs, extra)
+			stops[c.Language] = stop
+		}
+		tokens := TokenizeComment(c.Text)
+		if c.Language != "" {
+			tokens = TokenizerFor(c.Language).Tokenize(c.Text)
+		}
+		for _, w := range tokens {
+			if !stop[w] {
+				freq[w]++
+			}
+		}
+	}
+	return freq
+}
+
+// RemoveStopwords: 빈도 맵에서 불용어 제거
+func RemoveStopwords(freq map[string]int, stop map[string]bool) map[string]int {
+	out := make(map[string]int, len(freq))
+	for w, n := range freq {
+		if !stop[w] {
+			out[w] = n
+		}
+	}
+	return out
+}
+
+// ParseWordList: 쉼표/줄바꿈으로 구분된 입력값(불용어, 언어 코드 등) 파싱
+func ParseWordList(input string) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' })
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f != "" {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+type channelStopwords struct {
+	UserID    string   `firestore:"userId"`
+	ChannelID string   `firestore:"channelId"`
+	Words     []string `firestore:"words"`
+}
+
+// channelStopwordsDocID: 사용자+채널별 불용어 문서 ID
+// (다른 사용자의 분석에 영향을 주지 않도록 채널만으로 키를 만들지 않음)
+func channelStopwordsDocID(userID, channelID string) string {
+	return userID + "_" + channelID
+}
+
+// GetChannelStopwords: 사용자가 채널에 지정한 불용어 조회 (없으면 빈 목록)
+func GetChannelStopwords(ctx context.Context, userID, channelID string) ([]string, error) {
+	if firestoreClient == nil || userID == "" || channelID == "" {
+		return nil, nil
+	}
+	doc, err := firestoreClient.Collection("channelStopwords").Doc(channelStopwordsDocID(userID, channelID)).Get(ctx)
+	if err != nil {
+		if doc != nil && !doc.Exists() {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cs channelStopwords
+	doc.DataTo(&cs)
+	return cs.Words, nil
+}
+
+// SaveChannelStopwords: 사용자가 채널에 지정한 불용어 저장 (기존 목록 덮어씀)
+func SaveChannelStopwords(ctx context.Context, userID, channelID string, words []string) error {
+	if userID == "" {
+		return fmt.Errorf("사용자 ID가 없음")
+	}
+	_, err := firestoreClient.Collection("channelStopwords").Doc(channelStopwordsDocID(userID, channelID)).Set(ctx, channelStopwords{
+		UserID:    userID,
+		ChannelID: channelID,
+		Words:     words,
+	})
+	return err
+}
@@ -0,0 +1,425 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 감성 라벨별 차트 색상
+var sentimentColors = map[string]string{
+	"긍정": "#4caf50",
+	"부정": "#f44336",
+	"중립": "#9e9e9e",
+}
+
+// 감성 외 라벨(감정 등)과 워드클라우드에 돌려 쓰는 색상
+var chartPalette = []string{"#5470c6", "#91cc75", "#fac858", "#ee6666", "#73c0de", "#3ba272", "#fc8452", "#9a60b4", "#ea7ccc"}
+
+// SVG 차트 글꼴 (이메일 클라이언트에서도 되도록 시스템 한글 글꼴 우선)
+const svgFontFamily = `'Malgun Gothic','Apple SD Gothic Neo','Noto Sans KR',sans-serif`
+
+// 워드클라우드 글자 크기 범위와 최대 단어 수
+const (
+	cloudMinFont  = 12.0
+	cloudMaxFont  = 56.0
+	cloudMaxWords = 80
+)
+
+// svgBar: 가로 막대 차트 항목
+type svgBar struct {
+	Label string
+	Value float64
+	Text  string // 막대 오른쪽에 표시할 값 (비어 있으면 Value)
+	Color string
+}
+
+// svgSlice: 파이차트 조각
+type svgSlice struct {
+	Label   string
+	Count   int
+	Percent int
+	Color   string
+}
+
+// placedWord: 워드클라우드에 배치된 단어 (X, Y는 글자 상자의 중심)
+type placedWord struct {
+	Text     string
+	FontSize float64
+	X, Y     float64
+	W, H     float64
+	Color    string
+}
+
+// svgEscape: SVG 텍스트/속성 값 이스케이프
+func svgEscape(s string) string {
+	return xmlEscape(s)
+}
+
+func svgOpen(width, height int, title string) string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s" font-family="%s">`,
+		width, height, width, height, svgEscape(title), svgFontFamily)
+}
+
+// runeWidth: 글자 하나의 폭 (글자 크기 대비 비율). 한글/한자/가나/이모지는 전각, 라틴 문자는 대략적인 비례폭
+func runeWidth(r rune) float64 {
+	switch {
+	case unicode.Is(unicode.Hangul, r), unicode.Is(unicode.Han, r),
+		unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+		return 1.0
+	case r >= 0x1F300 || (r >= 0x2600 && r <= 0x27BF):
+		return 1.0 // 이모지, 기호
+	case r == ' ':
+		return 0.3
+	case strings.ContainsRune("il.,:;'|!`", r):
+		return 0.28
+	case strings.ContainsRune("fjrt()[]", r):
+		return 0.38
+	case r == 'm' || r == 'w' || r == 'M' || r == 'W':
+		return 0.85
+	case unicode.IsUpper(r), unicode.IsDigit(r):
+		return 0.64
+	case r < 0x80:
+		return 0.55
+	}
+	return 0.6
+}
+
+// textWidth: 글자 크기가 fontSize일 때 문자열의 예상 폭(px)
+func textWidth(s string, fontSize float64) float64 {
+	w := 0.0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w * fontSize
+}
+
+// WriteSVG: SVG 문자열을 응답이나 파일에 출력
+func WriteSVG(w io.Writer, svg string) error {
+	_, err := io.WriteString(w, svg)
+	return err
+}
+
+// SentimentPieSVG: 긍정/부정/중립 비율 파이차트 SVG (범례 포함)
+func SentimentPieSVG(shares []ShareEstimate, size int) string {
+	slices := make([]svgSlice, 0, len(shares))
+	for _, s := range shares {
+		slices = append(slices, svgSlice{Label: s.Label, Count: s.Count, Percent: s.Percent, Color: sentimentColors[s.Label]})
+	}
+	return pieSVG("감성 비율", slices, size)
+}
+
+// LabelPieSVG: 라벨 배열의 개수를 세어 만든 파이차트 SVG (감정 분포 등)
+func LabelPieSVG(title string, labels []string, size int) string {
+	count := map[string]int{}
+	for _, l := range labels {
+		count[l]++
+	}
+	slices := make([]svgSlice, 0, len(count))
+	for k, v := range count {
+		slices = append(slices, svgSlice{Label: k, Count: v})
+	}
+	sort.Slice(slices, func(i, j int) bool {
+		if slices[i].Count != slices[j].Count {
+			return slices[i].Count > slices[j].Count
+		}
+		return slices[i].Label < slices[j].Label
+	})
+	counts := make([]int, len(slices))
+	for i, sl := range slices {
+		counts[i] = sl.Count
+	}
+	percents := LargestRemainderPercents(counts)
+	for i := range slices {
+		slices[i].Percent = percents[i]
+		if c, ok := sentimentColors[slices[i].Label]; ok {
+			slices[i].Color = c
+		} else {
+			slices[i].Color = chartPalette[i%len(chartPalette)]
+		}
+	}
+	return pieSVG(title, slices, size)
+}
+
+// pieSVG: 조각 목록으로 파이차트와 오른쪽 범례 그리기
+func pieSVG(title string, slices []svgSlice, size int) string {
+	r := float64(size) / 2
+	total := 0
+	legendWidth := 0.0
+	for _, s := range slices {
+		total += s.Count
+		legendWidth = math.Max(legendWidth, textWidth(fmt.Sprintf("%s 100%%", s.Label), 13))
+	}
+	height := size
+	if h := 20 + len(slices)*24; h > height {
+		height = h
+	}
+	var sb strings.Builder
+	sb.WriteString(svgOpen(size+int(legendWidth)+48, height, title))
+	if total == 0 {
+		fmt.Fprintf(&sb, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="#eeeeee"/>`, r, r, r)
+	}
+	angle := -math.Pi / 2
+	for _, s := range slices {
+		if s.Count == 0 {
+			continue
+		}
+		if s.Count == total {
+			fmt.Fprintf(&sb, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`, r, r, r, s.Color)
+			break
+		}
+		sweep := 2 * math.Pi * float64(s.Count) / float64(total)
+		x1, y1 := r+r*math.Cos(angle), r+r*math.Sin(angle)
+		x2, y2 := r+r*math.Cos(angle+sweep), r+r*math.Sin(angle+sweep)
+		large := 0
+		if sweep > math.Pi {
+			large = 1
+		}
+		fmt.Fprintf(&sb, `<path d="M%.1f,%.1f L%.2f,%.2f A%.1f,%.1f 0 %d 1 %.2f,%.2f Z" fill="%s" stroke="#ffffff" stroke-width="1"/>`,
+			r, r, x1, y1, r, r, large, x2, y2, s.Color)
+		angle += sweep
+	}
+	for i, s := range slices {
+		y := 20 + i*24
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="14" height="14" fill="%s"/>`, size+16, y, s.Color)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="13">%s %d%%</text>`, size+36, y+12, svgEscape(s.Label), s.Percent)
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// SentimentBarsSVG: 감성별 비율 막대와 95% 신뢰구간 SVG
+func SentimentBarsSVG(shares []ShareEstimate, width int) string {
+	bars := make([]svgBar, 0, len(shares))
+	for _, s := range shares {
+		bars = append(bars, svgBar{
+			Label: s.Label,
+			Value: float64(s.Percent),
+			Text:  fmt.Sprintf("%d%% (%.1f~%.1f)", s.Percent, s.Lower, s.Upper),
+			Color: sentimentColors[s.Label],
+		})
+	}
+	return horizontalBarsSVG("감성 비율(%)", bars, 100, width)
+}
+
+// horizontalBarsSVG: 이름/막대/값으로 된 가로 막대 차트 (max는 막대 최대 길이에 해당하는 값)
+func horizontalBarsSVG(title string, bars []svgBar, max float64, width int) string {
+	const (
+		rowHeight = 26
+		top       = 30
+	)
+	labelWidth, valueWidth := 40.0, 0.0
+	for _, b := range bars {
+		labelWidth = math.Max(labelWidth, textWidth(truncateRunes(b.Label, 8), 13)+12)
+		text := b.Text
+		if text == "" {
+			text = fmt.Sprintf("%g", b.Value)
+		}
+		valueWidth = math.Max(valueWidth, textWidth(text, 12)+10)
+	}
+	height := top + len(bars)*rowHeight + 10
+	barArea := math.Max(float64(width)-labelWidth-valueWidth, 0)
+	var sb strings.Builder
+	sb.WriteString(svgOpen(width, height, title))
+	fmt.Fprintf(&sb, `<text x="0" y="18" font-size="14" font-weight="bold">%s</text>`, svgEscape(title))
+	for i, b := range bars {
+		y := top + i*rowHeight
+		length := 0.0
+		if max > 0 {
+			length = barArea * b.Value / max
+		}
+		text := b.Text
+		if text == "" {
+			text = fmt.Sprintf("%g", b.Value)
+		}
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d" font-size="13" text-anchor="end">%s</text>`, labelWidth-8, y+15, svgEscape(truncateRunes(b.Label, 8)))
+		fmt.Fprintf(&sb, `<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"/>`, labelWidth, y+2, length, rowHeight-8, b.Color)
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d" font-size="12" fill="#333333">%s</text>`, labelWidth+length+6, y+15, svgEscape(text))
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// TimelineSVG: 업로드 후 구간별 감성 개수를 누적 막대로 그린 SVG
+func TimelineSVG(tl SentimentTimeline, width, height int) string {
+	const (
+		left   = 40.0
+		right  = 10.0
+		top    = 40.0
+		bottom = 36.0
+	)
+	plotW, plotH := float64(width)-left-right, float64(height)-top-bottom
+	var sb strings.Builder
+	sb.WriteString(svgOpen(width, height, "업로드 후 감성 변화"))
+	sb.WriteString(`<text x="0" y="18" font-size="14" font-weight="bold">업로드 후 감성 변화</text>`)
+	// 범례
+	x := left
+	for _, label := range []string{"긍정", "중립", "부정"} {
+		fmt.Fprintf(&sb, `<rect x="%.1f" y="24" width="10" height="10" fill="%s"/>`, x, sentimentColors[label])
+		fmt.Fprintf(&sb, `<text x="%.1f" y="33" font-size="11">%s</text>`, x+14, label)
+		x += 14 + textWidth(label, 11) + 12
+	}
+	maxTotal := 0
+	for _, b := range tl.Buckets {
+		if t := b.PosCount + b.NegCount + b.NeuCount; t > maxTotal {
+			maxTotal = t
+		}
+	}
+	// 축과 눈금 (0, 절반, 최대)
+	fmt.Fprintf(&sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#999999"/>`, left, top+plotH, left+plotW, top+plotH)
+	for _, v := range []int{0, maxTotal / 2, maxTotal} {
+		if maxTotal == 0 && v > 0 {
+			break
+		}
+		y := top + plotH
+		if maxTotal > 0 {
+			y -= plotH * float64(v) / float64(maxTotal)
+		}
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" font-size="10" text-anchor="end" fill="#666666">%d</text>`, left-4, y+3, v)
+		if v > 0 {
+			fmt.Fprintf(&sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#eeeeee"/>`, left, y, left+plotW, y)
+		}
+	}
+	n := len(tl.Buckets)
+	if n == 0 || maxTotal == 0 {
+		sb.WriteString(`</svg>`)
+		return sb.String()
+	}
+	slot := plotW / float64(n)
+	barW := math.Max(slot*0.8, 1)
+	// x축 라벨은 겹치지 않을 만큼만 건너뛰며 표시
+	labelEvery := 1
+	if w := textWidth(tl.Buckets[n-1].Label, 10) + 8; w > slot {
+		labelEvery = int(math.Ceil(w / slot))
+	}
+	for i, b := range tl.Buckets {
+		bx := left + slot*float64(i) + (slot-barW)/2
+		base := top + plotH
+		for _, part := range []struct {
+			label string
+			count int
+		}{{"긍정", b.PosCount}, {"중립", b.NeuCount}, {"부정", b.NegCount}} {
+			if part.count == 0 {
+				continue
+			}
+			h := plotH * float64(part.count) / float64(maxTotal)
+			base -= h
+			fmt.Fprintf(&sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s %s %d</title></rect>`,
+				bx, base, barW, h, sentimentColors[part.label], svgEscape(b.Label), part.label, part.count)
+		}
+		if i%labelEvery == 0 {
+			fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" font-size="10" text-anchor="middle" fill="#666666">%s</text>`,
+				bx+barW/2, top+plotH+14, svgEscape(b.Label))
+		}
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// WordCloudSVG: 단어 빈도로 워드클라우드 SVG 생성 (빈도 높은 단어부터 나선을 따라 겹치지 않게 배치)
+func WordCloudSVG(freq map[string]int, width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(svgOpen(width, height, "워드클라우드"))
+	for _, p := range layoutWordCloud(freq, width, height, cloudMaxWords) {
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" font-size="%.0f" fill="%s" text-anchor="middle">%s</text>`,
+			p.X, p.Y+p.FontSize*0.35, p.FontSize, p.Color, svgEscape(p.Text))
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// layoutWordCloud: 상위 n개 단어를 빈도에 비례한 크기로 배치.
+// 가운데에서 시작하는 아르키메데스 나선을 따라 다른 단어와 겹치지 않는 첫 자리를 찾고,
+// 자리가 없으면 글자를 줄여 다시 시도하며 최소 크기에서도 안 되면 생략
+func layoutWordCloud(freq map[string]int, width, height, n int) []placedWord {
+	type kv struct {
+		word  string
+		count int
+	}
+	words := make([]kv, 0, len(freq))
+	for w, c := range freq {
+		if c > 0 && strings.TrimSpace(w) != "" {
+			words = append(words, kv{w, c})
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].count != words[j].count {
+			return words[i].count > words[j].count
+		}
+		return words[i].word < words[j].word
+	})
+	if len(words) > n {
+		words = words[:n]
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	maxCount, minCount := float64(words[0].count), float64(words[len(words)-1].count)
+	cx, cy := float64(width)/2, float64(height)/2
+	aspect := float64(width) / float64(height)
+	maxRadius := math.Hypot(cx, cy)
+	const pad = 2.0
+
+	placed := make([]placedWord, 0, len(words))
+	fits := func(p placedWord) bool {
+		if p.X-p.W/2 < 0 || p.X+p.W/2 > float64(width) || p.Y-p.H/2 < 0 || p.Y+p.H/2 > float64(height) {
+			return false
+		}
+		for _, q := range placed {
+			if math.Abs(p.X-q.X)*2 < p.W+q.W+pad && math.Abs(p.Y-q.Y)*2 < p.H+q.H+pad {
+				return false
+			}
+		}
+		return true
+	}
+	for i, w := range words {
+		size := cloudMaxFont
+		if maxCount > minCount {
+			size = cloudMinFont + (cloudMaxFont-cloudMinFont)*(float64(w.count)-minCount)/(maxCount-minCount)
+		}
+		for ; size >= cloudMinFont; size *= 0.8 {
+			p := placedWord{Text: w.word, FontSize: math.Round(size), Color: chartPalette[i%len(chartPalette)]}
+			p.W, p.H = textWidth(w.word, p.FontSize), p.FontSize*1.1
+			found := false
+			// 나선 간격은 글자 크기에 맞춰, 큰 단어는 성기게 작은 단어는 촘촘히 탐색
+			step := math.Max(p.FontSize/8, 1)
+			for t := 0.0; ; t += 0.15 {
+				r := step * t
+				if r > maxRadius {
+					break
+				}
+				p.X, p.Y = cx+r*math.Cos(t)*aspect, cy+r*math.Sin(t)
+				if fits(p) {
+					found = true
+					break
+				}
+			}
+			if found {
+				placed = append(placed, p)
+				break
+			}
+		}
+	}
+	return placed
+}
+
+// RenderResultSVG: 분석 결과 차트(wordcloud, pie, emotion, timeline)를 스크립트 없는 SVG로 출력
+func RenderResultSVG(w io.Writer, res *AnalysisResult, kind string) error {
+	switch kind {
+	case ChartWordCloud:
+		return WriteSVG(w, WordCloudSVG(res.WordFreq, 640, 400))
+	case ChartPie:
+		return WriteSVG(w, SentimentPieSVG(res.Shares, 200))
+	case ChartEmotion:
+		if res.Emotions == nil {
+			return fmt.Errorf("감정 분석 결과가 없음")
+		}
+		return WriteSVG(w, LabelPieSVG("감정분석", res.EmotionLabels(), 200))
+	case ChartTimeline:
+		return WriteSVG(w, TimelineSVG(res.Timeline, 640, 300))
+	}
+	return fmt.Errorf("알 수 없는 차트 종류: %s", kind)
+}
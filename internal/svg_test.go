@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// assertValidSVG: 이메일 클라이언트에서도 깨지지 않도록 올바른 XML이고 스크립트가 없는지 확인
+func assertValidSVG(t *testing.T, svg string) {
+	t.Helper()
+	if strings.Contains(svg, "<script") {
+		t.Errorf("SVG에 스크립트가 들어 있음")
+	}
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("올바르지 않은 SVG: %v\n%s", err, svg)
+		}
+	}
+}
+
+func TestSentimentPieSVG(t *testing.T) {
+	for _, shares := range [][]ShareEstimate{SentimentShares(3, 1, 2, 0), SentimentShares(5, 0, 0, 0), SentimentShares(0, 0, 0, 0)} {
+		assertValidSVG(t, SentimentPieSVG(shares, 160))
+	}
+	assertValidSVG(t, LabelPieSVG("감정분석", []string{"기쁨", "분노", "기쁨", "<슬픔>"}, 160))
+}
+
+func TestTimelineSVG(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tl := SentimentTimeline{Unit: BucketHour}
+	for i := 0; i < 48; i++ {
+		tl.Buckets = append(tl.Buckets, TimelineBucket{Offset: i, Start: start.Add(time.Duration(i) * time.Hour), Label: fmt.Sprintf("%d시간", i), PosCount: i % 5, NegCount: 1, NeuCount: 2})
+	}
+	svg := TimelineSVG(tl, 640, 300)
+	assertValidSVG(t, svg)
+	// 범례 3개 + 구간별 막대 3개 (긍정이 0인 구간 10개는 긍정 막대 생략)
+	if got := strings.Count(svg, "<rect"); got != 3+48*3-10 {
+		t.Errorf("사각형 %d개, 기대값 %d개", got, 3+48*3-10)
+	}
+	assertValidSVG(t, TimelineSVG(SentimentTimeline{}, 640, 300))
+}
+
+func TestTextWidth(t *testing.T) {
+	if ko, en := textWidth("안녕하세요", 10), textWidth("hello", 10); ko != 50 || en >= ko {
+		t.Errorf("textWidth 한글=%v, 영문=%v", ko, en)
+	}
+}
+
+func TestLayoutWordCloud(t *testing.T) {
+	freq := map[string]int{}
+	for i := 0; i < 100; i++ {
+		freq[fmt.Sprintf("단어%d", i)] = 100 - i
+	}
+	freq["english"] = 60
+	words := layoutWordCloud(freq, 640, 400, cloudMaxWords)
+	if len(words) < 30 {
+		t.Fatalf("배치된 단어가 %d개뿐", len(words))
+	}
+	if words[0].Text != "단어0" || words[0].FontSize != cloudMaxFont {
+		t.Errorf("가장 많이 나온 단어가 최대 크기로 먼저 배치되지 않음: %+v", words[0])
+	}
+	for i, p := range words {
+		if p.X-p.W/2 < 0 || p.X+p.W/2 > 640 || p.Y-p.H/2 < 0 || p.Y+p.H/2 > 400 {
+			t.Errorf("%s가 영역을 벗어남: %+v", p.Text, p)
+		}
+		for _, q := range words[:i] {
+			if math.Abs(p.X-q.X)*2 < p.W+q.W && math.Abs(p.Y-q.Y)*2 < p.H+q.H {
+				t.Errorf("%s와 %s가 겹침", p.Text, q.Text)
+			}
+		}
+	}
+	// 같은 입력이면 같은 배치
+	if again := layoutWordCloud(freq, 640, 400, cloudMaxWords); fmt.Sprint(again) != fmt.Sprint(words) {
+		t.Error("배치 결과가 매번 다름")
+	}
+	assertValidSVG(t, WordCloudSVG(map[string]int{"<b>&": 3, "좋아요": 5}, 320, 200))
+	if got := layoutWordCloud(nil, 320, 200, 10); got != nil {
+		t.Errorf("빈 빈도표 배치 결과 = %v", got)
+	}
+}
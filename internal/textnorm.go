@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	brTagRe     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	anchorRe    = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	tagRe       = regexp.MustCompile(`(?s)<[^>]+>`)
+	urlRe       = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+	timestampRe = regexp.MustCompile(`\b(?:\d{1,2}:)?[0-5]?\d:[0-5]\d\b`)
+)
+
+// NormalizedText: 정리된 댓글 본문과 본문에서 뽑은 구조화 정보
+type NormalizedText struct {
+	Text       string
+	Timestamps []string // 영상 타임스탬프 (예: "1:23", "01:02:03")
+	Links      []string
+}
+
+// NormalizeCommentText: HTML 태그 제거, 엔티티 디코딩 후 링크는 본문에서 빼서 Links로,
+// 타임스탬프는 본문에 남긴 채 Timestamps로 추출
+func NormalizeCommentText(raw string) NormalizedText {
+	var links []string
+	text := brTagRe.ReplaceAllString(raw, "\n")
+	// <a href="..."> 태그: 타임스탬프 링크는 표시 텍스트만, 나머지는 링크로 추출
+	text = anchorRe.ReplaceAllStringFunc(text, func(a string) string {
+		m := anchorRe.FindStringSubmatch(a)
+		label := tagRe.ReplaceAllString(m[2], "")
+		if timestampRe.MatchString(label) {
+			return label
+		}
+		links = append(links, html.UnescapeString(m[1]))
+		return ""
+	})
+	text = tagRe.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = urlRe.ReplaceAllStringFunc(text, func(u string) string {
+		links = append(links, u)
+		return ""
+	})
+	text = strings.TrimSpace(spaceRe.ReplaceAllStringFunc(text, func(s string) string {
+		if strings.Contains(s, "\n") {
+			return "\n"
+		}
+		return " "
+	}))
+	return NormalizedText{
+		Text:       text,
+		Timestamps: timestampRe.FindAllString(text, -1),
+		Links:      links,
+	}
+}
+
+// SquashRepeatedChars: 같은 글자가 3번 이상 반복되면 2번으로 줄임 ("ㅋㅋㅋㅋㅋ" → "ㅋㅋ")
+func SquashRepeatedChars(text string) string {
+	var sb strings.Builder
+	var prev rune
+	run := 0
+	for _, r := range text {
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run <= 2 {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// NormalizeEmoji: 이모지 변형 선택자와 피부색 수정자를 제거해 같은 이모지를 하나로 취급
+func NormalizeEmoji(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == 0xFE0E || r == 0xFE0F: // 변형 선택자
+			return -1
+		case r >= 0x1F3FB && r <= 0x1F3FF: // 피부색 수정자
+			return -1
+		}
+		return r
+	}, text)
+}
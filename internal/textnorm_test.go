@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeCommentText(t *testing.T) {
+	raw := `<a href="https://www.youtube.com/watch?v=abcdefghijk&amp;t=83">1:23</a> 여기 &quot;최고&quot;<br>It&#39;s great <a href="https://example.com/shop">https://example.com/shop</a>`
+	got := NormalizeCommentText(raw)
+	want := NormalizedText{
+		Text:       "1:23 여기 \"최고\"\nIt's great",
+		Timestamps: []string{"1:23"},
+		Links:      []string{"https://example.com/shop"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeCommentText = %#v, want %#v", got, want)
+	}
+
+	plain := NormalizeCommentText("01:02:03 부분 미쳤다 https://t.me/abc")
+	if plain.Text != "01:02:03 부분 미쳤다" || !reflect.DeepEqual(plain.Timestamps, []string{"01:02:03"}) ||
+		!reflect.DeepEqual(plain.Links, []string{"https://t.me/abc"}) {
+		t.Errorf("원문 정규화 결과 = %#v", plain)
+	}
+}
+
+func TestSquashAndEmoji(t *testing.T) {
+	if got := SquashRepeatedChars("ㅋㅋㅋㅋㅋ 좋아아아아!!!!"); got != "ㅋㅋ 좋아아!!" {
+		t.Errorf("SquashRepeatedChars = %q", got)
+	}
+	if got := NormalizeEmoji("👍🏻❤️"); got != "👍❤" {
+		t.Errorf("NormalizeEmoji = %q", got)
+	}
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// 타임라인 구간 단위
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// 자동 단위 선택시 시간 단위를 쓰는 최대 기간, 구간 수 상한
+const (
+	hourlyTimelineMaxSpan = 72 * time.Hour
+	maxTimelineBuckets    = 400
+)
+
+// TimelineBucket: 업로드 후 경과 시간 구간별 감성 개수
+type TimelineBucket struct {
+	Offset   int       `json:"offset"` // 업로드 후 몇 번째 구간인지 (시간, 일 또는 주)
+	Start    time.Time `json:"start"`
+	Label    string    `json:"label"`
+	PosCount int       `json:"posCount"`
+	NegCount int       `json:"negCount"`
+	NeuCount int       `json:"neuCount"`
+}
+
+// SentimentTimeline: 감성 타임라인 (Unit은 hour, day 또는 week)
+type SentimentTimeline struct {
+	Unit    string           `json:"unit"`
+	Buckets []TimelineBucket `json:"buckets"`
+}
+
+// BuildSentimentTimeline: 댓글 작성 시각을 업로드 시각 기준 구간으로 묶어 감성 개수 집계.
+// unit이 비어 있으면 기간이 72시간 이하일 때 hour, 아니면 day.
+// 구간이 maxTimelineBuckets개를 넘으면 더 큰 단위로 넓히고, week로도 넘치면 마지막 구간을 "+N주 이후"로 묶음
+func BuildSentimentTimeline(comments []Comment, labels []string, uploadedAt time.Time, unit string) SentimentTimeline {
+	var first, last time.Time
+	for _, c := range comments {
+		if c.PublishedAt.IsZero() {
+			continue
+		}
+		if first.IsZero() || c.PublishedAt.Before(first) {
+			first = c.PublishedAt
+		}
+		if c.PublishedAt.After(last) {
+			last = c.PublishedAt
+		}
+	}
+	if first.IsZero() {
+		return SentimentTimeline{Unit: unit}
+	}
+	if uploadedAt.IsZero() || uploadedAt.After(first) {
+		uploadedAt = first
+	}
+	if unit != BucketHour && unit != BucketDay && unit != BucketWeek {
+		unit = BucketDay
+		if last.Sub(uploadedAt) <= hourlyTimelineMaxSpan {
+			unit = BucketHour
+		}
+	}
+	size := time.Hour
+	switch unit {
+	case BucketDay:
+		size = 24 * time.Hour
+	case BucketWeek:
+		size = 7 * 24 * time.Hour
+	}
+	n := int(last.Sub(uploadedAt)/size) + 1
+	if n > maxTimelineBuckets {
+		switch unit {
+		case BucketHour:
+			return BuildSentimentTimeline(comments, labels, uploadedAt, BucketDay)
+		case BucketDay:
+			return BuildSentimentTimeline(comments, labels, uploadedAt, BucketWeek)
+		}
+	}
+	openEnded := n > maxTimelineBuckets
+	if openEnded {
+		n = maxTimelineBuckets
+	}
+	buckets := make([]TimelineBucket, n)
+	for i := range buckets {
+		buckets[i] = TimelineBucket{Offset: i, Start: uploadedAt.Add(time.Duration(i) * size), Label: timelineLabel(i, unit)}
+	}
+	if openEnded {
+		buckets[n-1].Label += " 이후"
+	}
+	for i, c := range comments {
+		if c.PublishedAt.IsZero() || i >= len(labels) {
+			continue
+		}
+		idx := int(c.PublishedAt.Sub(uploadedAt) / size)
+		if idx >= n {
+			idx = n - 1
+		}
+		switch labels[i] {
+		case "긍정":
+			buckets[idx].PosCount++
+		case "부정":
+			buckets[idx].NegCount++
+		case "중립":
+			buckets[idx].NeuCount++
+		}
+	}
+	return SentimentTimeline{Unit: unit, Buckets: buckets}
+}
+
+func timelineLabel(offset int, unit string) string {
+	switch unit {
+	case BucketHour:
+		return fmt.Sprintf("+%d시간", offset)
+	case BucketWeek:
+		return fmt.Sprintf("+%d주", offset)
+	}
+	return fmt.Sprintf("+%d일", offset)
+}
+
+// GenerateTimelineChart: 업로드 후 경과 시간별 긍정/부정/중립 개수 누적 영역 차트 생성
+func GenerateTimelineChart(tl SentimentTimeline, filePath string) error {
+	return saveChart(timelineChart(tl), filePath)
+}
+
+func timelineChart(tl SentimentTimeline) *charts.Line {
+	xAxis := make([]string, 0, len(tl.Buckets))
+	pos := make([]opts.LineData, 0, len(tl.Buckets))
+	neg := make([]opts.LineData, 0, len(tl.Buckets))
+	neu := make([]opts.LineData, 0, len(tl.Buckets))
+	for _, b := range tl.Buckets {
+		xAxis = append(xAxis, b.Label)
+		pos = append(pos, opts.LineData{Value: b.PosCount})
+		neg = append(neg, opts.LineData{Value: b.NegCount})
+		neu = append(neu, opts.LineData{Value: b.NeuCount})
+	}
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{Title: "업로드 후 감성 변화"}),
+		charts.WithTooltipOpts(opts.Tooltip{Show: opts.Bool(true), Trigger: "axis"}),
+	)
+	line.SetXAxis(xAxis)
+	stack := charts.WithLineChartOpts(opts.LineChart{Stack: "감성", Smooth: opts.Bool(true)})
+	area := charts.WithAreaStyleOpts(opts.AreaStyle{Opacity: 0.6})
+	line.AddSeries("긍정", pos, stack, area)
+	line.AddSeries("중립", neu, stack, area)
+	line.AddSeries("부정", neg, stack, area)
+	return line
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildSentimentTimeline(t *testing.T) {
+	upload := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	comments := []Comment{
+		{PublishedAt: upload.Add(10 * time.Minute)},
+		{PublishedAt: upload.Add(30 * time.Minute)},
+		{PublishedAt: upload.Add(5*time.Hour + time.Minute)},
+		{}, // 작성 시각 없음
+	}
+	labels := []string{"긍정", "부정", "부정", "긍정"}
+
+	tl := BuildSentimentTimeline(comments, labels, upload, "")
+	if tl.Unit != BucketHour || len(tl.Buckets) != 6 {
+		t.Fatalf("단위 = %s, 구간 수 = %d, want hour, 6", tl.Unit, len(tl.Buckets))
+	}
+	if b := tl.Buckets[0]; b.PosCount != 1 || b.NegCount != 1 || b.Label != "+0시간" {
+		t.Errorf("첫 구간 = %+v", b)
+	}
+	if b := tl.Buckets[5]; b.NegCount != 1 {
+		t.Errorf("마지막 구간 = %+v", b)
+	}
+
+	daily := BuildSentimentTimeline(comments, labels, upload, BucketDay)
+	if len(daily.Buckets) != 1 || daily.Buckets[0].NegCount != 2 {
+		t.Errorf("일 단위 타임라인 = %+v", daily)
+	}
+}
+
+func TestBuildSentimentTimelineLongSpan(t *testing.T) {
+	upload := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	cases := []struct {
+		name      string
+		last      time.Duration // 마지막 댓글의 업로드 후 경과 시간
+		unit      string
+		wantUnit  string
+		wantN     int
+		wantLabel string // 마지막 구간 라벨
+	}{
+		{"400일 이내", 399 * day, "", BucketDay, 400, "+399일"},
+		{"400일 초과는 주 단위", 500 * day, "", BucketWeek, 72, "+71주"},
+		{"시간 단위 요청도 넘치면 주 단위", 1000 * day, BucketHour, BucketWeek, 143, "+142주"},
+		{"주 단위로도 넘치면 마지막 구간을 묶음", 3000 * day, "", BucketWeek, maxTimelineBuckets, "+399주 이후"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			comments := []Comment{{PublishedAt: upload.Add(time.Hour)}, {PublishedAt: upload.Add(tc.last)}}
+			tl := BuildSentimentTimeline(comments, []string{"긍정", "부정"}, upload, tc.unit)
+			if tl.Unit != tc.wantUnit || len(tl.Buckets) != tc.wantN {
+				t.Fatalf("단위 = %s, 구간 수 = %d, 기대값 %s, %d", tl.Unit, len(tl.Buckets), tc.wantUnit, tc.wantN)
+			}
+			lastBucket := tl.Buckets[len(tl.Buckets)-1]
+			if lastBucket.Label != tc.wantLabel || lastBucket.NegCount != 1 {
+				t.Errorf("마지막 구간 = %+v, 기대 라벨 %s", lastBucket, tc.wantLabel)
+			}
+		})
+	}
+}
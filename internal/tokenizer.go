@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer: 언어별 단어 분리기 (정규화된 토큰 반환)
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// 언어 코드별 토크나이저 (RegisterTokenizer로 추가/교체)
+var tokenizers = map[string]Tokenizer{
+	"ko": KoreanTokenizer{},
+	"en": SimpleTokenizer{},
+	"es": SimpleTokenizer{},
+	"pt": SimpleTokenizer{},
+	"fr": SimpleTokenizer{},
+	"de": SimpleTokenizer{},
+	"id": SimpleTokenizer{},
+	"vi": SimpleTokenizer{},
+	"ru": SimpleTokenizer{},
+	"ja": CJKBigramTokenizer{},
+	"zh": CJKBigramTokenizer{},
+}
+
+// 언어를 모르거나 등록되지 않았을 때 사용하는 언어
+const defaultTokenLang = "ko"
+
+var wordRe = regexp.MustCompile(`\p{L}+[\p{L}\p{N}']*`)
+
+// RegisterTokenizer: 언어 코드에 토크나이저 등록
+func RegisterTokenizer(lang string, t Tokenizer) {
+	tokenizers[lang] = t
+}
+
+// TokenizerFor: 언어 코드에 맞는 토크나이저 반환 (없으면 기본 언어)
+func TokenizerFor(lang string) Tokenizer {
+	if t, ok := tokenizers[lang]; ok {
+		return t
+	}
+	if t, ok := tokenizers[defaultTokenLang]; ok {
+		return t
+	}
+	return SimpleTokenizer{}
+}
+
+// TokenizeComment: 댓글 언어를 감지해 해당 언어 토크나이저로 분리
+func TokenizeComment(text string) []string {
+	return TokenizerFor(DetectLanguage(text)).Tokenize(text)
+}
+
+// SimpleTokenizer: 문자 단위 정규식으로 분리하고 소문자로 변환 (한 글자 단어 제외)
+type SimpleTokenizer struct{}
+
+func (SimpleTokenizer) Tokenize(text string) []string {
+	words := wordRe.FindAllString(strings.ToLower(text), -1)
+	tokens := make([]string, 0, len(words))
+	for _, w := range words {
+		if utf8.RuneCountInString(w) < 2 {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// KoreanTokenizer: 한글 단어의 조사/어미를 떼어 어간(기본형)으로 정규화. 한글이 아닌 단어는 SimpleTokenizer와 동일
+type KoreanTokenizer struct{}
+
+func (KoreanTokenizer) Tokenize(text string) []string {
+	words := wordRe.FindAllString(strings.ToLower(text), -1)
+	tokens := make([]string, 0, len(words))
+	for _, w := range words {
+		if isHangulWord(w) {
+			w = KoreanStem(w)
+		}
+		if utf8.RuneCountInString(w) < 2 { // 한 글자 단어 제외 (바이트가 아닌 글자 수 기준)
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// CJKBigramTokenizer: 띄어쓰기가 없는 일본어/중국어는 한자·가나 연속 구간을 두 글자씩 겹쳐 분리.
+// 그 외 문자로 된 단어는 SimpleTokenizer와 동일
+type CJKBigramTokenizer struct{}
+
+func (CJKBigramTokenizer) Tokenize(text string) []string {
+	var tokens []string
+	for _, w := range wordRe.FindAllString(strings.ToLower(text), -1) {
+		if !strings.ContainsFunc(w, isCJK) {
+			if utf8.RuneCountInString(w) >= 2 {
+				tokens = append(tokens, w)
+			}
+			continue
+		}
+		// 한자·가나 구간과 나머지 구간을 나눠 각각 처리
+		runes := []rune(w)
+		for start := 0; start < len(runes); {
+			end := start + 1
+			for end < len(runes) && isCJK(runes[end]) == isCJK(runes[start]) {
+				end++
+			}
+			seg := runes[start:end]
+			if isCJK(seg[0]) {
+				for i := 0; i+1 < len(seg); i++ {
+					tokens = append(tokens, string(seg[i:i+2]))
+				}
+			} else if len(seg) >= 2 {
+				tokens = append(tokens, string(seg))
+			}
+			start = end
+		}
+	}
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// 용언 어미 → 기본형 어미 (긴 것부터 검사)
+var koreanEndings = []struct {
+	suffix, replace string
+}{
+	// 하다 용언
+	{"했습니다", "하다"}, {"했었어요", "하다"}, {"합니다", "하다"}, {"했어요", "하다"}, {"하네요", "하다"},
+	{"하지만", "하다"}, {"해서", "하다"}, {"해요", "하다"}, {"했다", "하다"}, {"한다", "하다"}, {"하는", "하다"},
+	// 서술격 조사(이다): 명사만 남김
+	{"입니다", ""}, {"이에요", ""}, {"이네요", ""}, {"이었다", ""}, {"이다", ""},
+	// 일반 어미
+	{"었습니다", "다"}, {"았습니다", "다"}, {"습니다", "다"}, {"었어요", "다"}, {"았어요", "다"}, {"였어요", "다"},
+	{"네요", "다"}, {"어요", "다"}, {"아요", "다"}, {"지요", "다"}, {"는데", "다"}, {"지만", "다"},
+	{"었다", "다"}, {"았다", "다"}, {"다고", "다"},
+}
+
+// 조사 (긴 것부터 검사)
+var koreanJosa = []string{
+	"으로부터", "에게서", "이라도", "이라고", "에서는", "에서도", "에게는", "까지는", "부터는", "으로는",
+	"에서", "에게", "한테", "으로", "부터", "까지", "처럼", "보다", "이랑", "이나", "마저", "조차", "밖에", "라고",
+	"은", "는", "이", "가", "을", "를", "의", "에", "로", "와", "과", "도", "만", "랑",
+}
+
+// 조사처럼 끝나지만 그 글자까지가 명사인 단어 (사전 없이 떼면 "고양이"가 "고양"이 되는 경우)
+var koreanJosaExceptions = map[string]bool{
+	"고양이": true, "어린이": true, "원숭이": true, "호랑이": true, "멍멍이": true, "야옹이": true, "꼬맹이": true,
+	"플레이": true, "디스플레이": true, "릴레이": true, "스프레이": true, "에세이": true,
+	"만족도": true, "완성도": true, "몰입도": true, "난이도": true, "인지도": true, "신뢰도": true, "선호도": true,
+	"집중도": true, "어느정도": true, "제주도": true, "고속도로": true, "민주주의": true, "자본주의": true,
+}
+
+// 받침 없는 명사 뒤의 서술격 조사 ("고양이예요", "고양이다")
+var koreanVowelCopula = map[string]bool{"다": true, "야": true, "예요": true, "에요": true, "네요": true, "였다": true, "였어요": true, "입니다": true}
+
+// KoreanStem: 한글 단어에서 어미나 조사를 떼어 기본형 반환.
+// 조사는 남는 어간이 두 글자 이상이고 예외 명사(koreanJosaExceptions)가 아닐 때만 떼어
+// "같이", "아이", "고양이" 같은 단어를 보존
+func KoreanStem(word string) string {
+	for noun := range koreanJosaExceptions {
+		if rest, ok := strings.CutPrefix(word, noun); ok && (rest == "" || koreanVowelCopula[rest] || containsString(koreanJosa, rest)) {
+			return noun
+		}
+	}
+	for _, e := range koreanEndings {
+		if strings.HasSuffix(word, e.suffix) {
+			stem := strings.TrimSuffix(word, e.suffix)
+			if stem == "" {
+				break
+			}
+			return stem + e.replace
+		}
+	}
+	for _, j := range koreanJosa {
+		if strings.HasSuffix(word, j) {
+			stem := strings.TrimSuffix(word, j)
+			if utf8.RuneCountInString(stem) >= 2 {
+				return stem
+			}
+		}
+	}
+	return word
+}
+
+// isHangulWord: 한글 음절이 포함된 단어인지
+func isHangulWord(w string) bool {
+	for _, r := range w {
+		if unicode.Is(unicode.Hangul, r) && r >= 0xAC00 && r <= 0xD7A3 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKoreanStem(t *testing.T) {
+	cases := map[string]string{
+		"영상이":   "영상",
+		"영상을":   "영상",
+		"영상은":   "영상",
+		"영상에서":  "영상",
+		"좋아요":   "좋다",
+		"좋네요":   "좋다",
+		"맛있어요":  "맛있다",
+		"감사합니다": "감사하다",
+		"사랑해요":  "사랑하다",
+		"대박이다":  "대박",
+		"같이":    "같이",
+		"노래":    "노래",
+		"아이":    "아이",
+		"아이가":   "아이",
+		"고양이":   "고양이",
+		"고양이가":  "고양이",
+		"고양이에요": "고양이",
+		"고양이랑":  "고양이",
+		"어린이":   "어린이",
+		"만족도":   "만족도",
+		"완성도가":  "완성도",
+	}
+	for in, want := range cases {
+		if got := KoreanStem(in); got != want {
+			t.Errorf("KoreanStem(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKoreanTokenizer(t *testing.T) {
+	got := KoreanTokenizer{}.Tokenize("영상이 너무 좋아요! 이 영상을 Best 영상은 봄")
+	want := []string{"영상", "너무", "좋다", "영상", "best", "영상"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,359 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+// 토픽 군집 설정
+const (
+	topicMinClusters    = 2
+	topicMaxClusters    = 8
+	topicKMeansIters    = 30
+	topicTFIDFVocab     = 500 // TF-IDF 벡터에 쓰는 최대 단어 수
+	topicKeywordsPerTop = 3
+	topicExamplesPerTop = 3
+)
+
+// 멈춘 임베딩 요청이 분석 동시 실행 자리를 계속 차지하지 않도록 제한 시간을 둔 클라이언트
+var embeddingsClient = &http.Client{Timeout: 30 * time.Second}
+
+// 토픽 벡터화 방식
+const (
+	TopicByEmbedding = "embedding"
+	TopicByTFIDF     = "tfidf"
+)
+
+// TopicExample: 토픽 대표 댓글 (Ref는 1부터 시작하는 댓글 번호)
+type TopicExample struct {
+	Ref       int    `json:"ref"`
+	Text      string `json:"text"`
+	Sentiment string `json:"sentiment"`
+}
+
+// TopicCluster: 비슷한 댓글 묶음과 키워드, 감성 분포, 예시 댓글
+type TopicCluster struct {
+	ID       int            `json:"id"`
+	Keywords []string       `json:"keywords"`
+	Size     int            `json:"size"`
+	PosCount int            `json:"posCount"`
+	NegCount int            `json:"negCount"`
+	NeuCount int            `json:"neuCount"`
+	Examples []TopicExample `json:"examples"`
+}
+
+// TopicResult: 토픽 군집 결과와 사용한 벡터화 방식
+type TopicResult struct {
+	Method   string         `json:"method"`
+	Clusters []TopicCluster `json:"clusters"`
+}
+
+// ClusterComments: 댓글을 토픽으로 군집. useEmbeddings이면 임베딩 API를 쓰고, 실패하거나 아니면 TF-IDF 벡터 사용.
+// k가 0이면 댓글 수에 따라 자동 결정
+func ClusterComments(comments []Comment, labels []string, k int, useEmbeddings bool, stop map[string]bool) TopicResult {
+	if len(comments) < topicMinClusters {
+		return TopicResult{}
+	}
+	texts := make([]string, len(comments))
+	for i, c := range comments {
+		texts[i] = c.Text
+	}
+	method := TopicByTFIDF
+	var vectors [][]float64
+	if useEmbeddings {
+		if emb, err := FetchEmbeddings(texts); err == nil && len(emb) == len(texts) {
+			vectors, method = emb, TopicByEmbedding
+		}
+	}
+	tokens := make([][]string, len(texts))
+	for i, t := range texts {
+		for _, w := range TokenizeComment(t) {
+			if !stop[w] {
+				tokens[i] = append(tokens[i], w)
+			}
+		}
+	}
+	if vectors == nil {
+		vectors = tfidfVectors(tokens)
+	}
+	for _, v := range vectors {
+		normalizeVector(v)
+	}
+	if k <= 0 {
+		k = int(math.Round(math.Sqrt(float64(len(comments)) / 2)))
+	}
+	if k < topicMinClusters {
+		k = topicMinClusters
+	}
+	if k > topicMaxClusters {
+		k = topicMaxClusters
+	}
+	if k > len(comments) {
+		k = len(comments)
+	}
+	assign, centroids := kMeans(vectors, k)
+	return TopicResult{Method: method, Clusters: buildTopicClusters(comments, labels, tokens, vectors, assign, centroids)}
+}
+
+// FetchEmbeddings: OpenAI 호환 임베딩 API로 댓글 벡터 조회.
+// OPENAI_EMBEDDINGS_URL, OPENAI_EMBEDDING_MODEL로 엔드포인트와 모델 변경 가능
+func FetchEmbeddings(texts []string) ([][]float64, error) {
+	url := os.Getenv("OPENAI_EMBEDDINGS_URL")
+	if url == "" {
+		url = "https://api.openai.com/v1/embeddings"
+	}
+	model := os.Getenv("OPENAI_EMBEDDING_MODEL")
+	if model == "" {
+		model = "text-embedding-3-small"
+	}
+	jsonBody, _ := json.Marshal(map[string]interface{}{"model": model, "input": texts})
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("OPENAI_API_KEY"))
+	resp, err := embeddingsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// 오류 응답은 JSON이 아닐 수도 있으므로 메시지를 읽지 못하면 상태만 표시
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16)); err == nil && json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("임베딩 API 오류(%d): %s", resp.StatusCode, apiErr.Error.Message)
+		}
+		return nil, fmt.Errorf("임베딩 API 오류: %s", resp.Status)
+	}
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	vectors := make([][]float64, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(vectors) {
+			return nil, fmt.Errorf("임베딩 응답의 index %d가 범위를 벗어남", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("%d번째 댓글의 임베딩이 없음", i)
+		}
+	}
+	return vectors, nil
+}
+
+// tfidfVectors: 댓글별 토큰으로 TF-IDF 벡터 생성 (빈도 상위 topicTFIDFVocab개 단어 기준)
+func tfidfVectors(tokens [][]string) [][]float64 {
+	df := map[string]int{}
+	for _, ts := range tokens {
+		seen := map[string]bool{}
+		for _, t := range ts {
+			if !seen[t] {
+				df[t]++
+				seen[t] = true
+			}
+		}
+	}
+	vocab := TopNWords(df, topicTFIDFVocab)
+	index := make(map[string]int, len(vocab))
+	for i, w := range vocab {
+		index[w] = i
+	}
+	n := float64(len(tokens))
+	vectors := make([][]float64, len(tokens))
+	for i, ts := range tokens {
+		v := make([]float64, len(vocab))
+		for _, t := range ts {
+			if j, ok := index[t]; ok {
+				v[j]++
+			}
+		}
+		for j, w := range vocab {
+			if v[j] > 0 {
+				v[j] *= math.Log(n/float64(df[w])) + 1
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors
+}
+
+func normalizeVector(v []float64) {
+	norm := 0.0
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm == 0 {
+		return
+	}
+	norm = math.Sqrt(norm)
+	for i := range v {
+		v[i] /= norm
+	}
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+// kMeans: 정규화된 벡터의 코사인 유사도 기준 구면 k-means (k-means++ 초기화, 결과 재현을 위해 고정 시드)
+func kMeans(vectors [][]float64, k int) ([]int, [][]float64) {
+	rng := rand.New(rand.NewSource(1))
+	dim := len(vectors[0])
+	centroids := make([][]float64, 0, k)
+	centroids = append(centroids, append([]float64(nil), vectors[rng.Intn(len(vectors))]...))
+	for len(centroids) < k {
+		dists := make([]float64, len(vectors))
+		total := 0.0
+		for i, v := range vectors {
+			best := math.Inf(1)
+			for _, c := range centroids {
+				if d := 1 - dot(v, c); d < best {
+					best = d
+				}
+			}
+			dists[i] = best * best
+			total += dists[i]
+		}
+		next := rng.Intn(len(vectors))
+		if total > 0 {
+			r := rng.Float64() * total
+			for i, d := range dists {
+				r -= d
+				if r <= 0 {
+					next = i
+					break
+				}
+			}
+		}
+		centroids = append(centroids, append([]float64(nil), vectors[next]...))
+	}
+
+	assign := make([]int, len(vectors))
+	for i := range assign {
+		assign[i] = -1
+	}
+	for iter := 0; iter < topicKMeansIters; iter++ {
+		changed := false
+		for i, v := range vectors {
+			best, bestSim := 0, math.Inf(-1)
+			for c, centroid := range centroids {
+				if sim := dot(v, centroid); sim > bestSim {
+					best, bestSim = c, sim
+				}
+			}
+			if assign[i] != best {
+				assign[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		for c := range centroids {
+			sum := make([]float64, dim)
+			for i, v := range vectors {
+				if assign[i] == c {
+					for j := range v {
+						sum[j] += v[j]
+					}
+				}
+			}
+			normalizeVector(sum)
+			centroids[c] = sum
+		}
+	}
+	return assign, centroids
+}
+
+// buildTopicClusters: 군집별 키워드(군집 내 빈도 × 군집 간 희소성), 감성 분포, 중심에 가까운 예시 댓글 정리
+func buildTopicClusters(comments []Comment, labels []string, tokens [][]string, vectors [][]float64, assign []int, centroids [][]float64) []TopicCluster {
+	k := len(centroids)
+	termByCluster := make([]map[string]int, k)
+	for c := range termByCluster {
+		termByCluster[c] = map[string]int{}
+	}
+	clustersWithTerm := map[string]int{}
+	for i, ts := range tokens {
+		for _, t := range ts {
+			if termByCluster[assign[i]][t] == 0 {
+				clustersWithTerm[t]++
+			}
+			termByCluster[assign[i]][t]++
+		}
+	}
+	clusters := make([]TopicCluster, 0, k)
+	for c := 0; c < k; c++ {
+		members := []int{}
+		for i, a := range assign {
+			if a == c {
+				members = append(members, i)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		cl := TopicCluster{Size: len(members)}
+		scores := map[string]float64{}
+		for t, n := range termByCluster[c] {
+			scores[t] = float64(n) * math.Log(1+float64(k)/float64(clustersWithTerm[t]))
+		}
+		cl.Keywords = TopNScored(scores, topicKeywordsPerTop)
+		for _, i := range members {
+			if i >= len(labels) {
+				continue
+			}
+			switch labels[i] {
+			case "긍정":
+				cl.PosCount++
+			case "부정":
+				cl.NegCount++
+			case "중립":
+				cl.NeuCount++
+			}
+		}
+		sort.SliceStable(members, func(a, b int) bool {
+			return dot(vectors[members[a]], centroids[c]) > dot(vectors[members[b]], centroids[c])
+		})
+		for _, i := range members {
+			if len(cl.Examples) >= topicExamplesPerTop {
+				break
+			}
+			ex := TopicExample{Ref: i + 1, Text: comments[i].Text}
+			if i < len(labels) {
+				ex.Sentiment = labels[i]
+			}
+			cl.Examples = append(cl.Examples, ex)
+		}
+		clusters = append(clusters, cl)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].Size > clusters[j].Size })
+	for i := range clusters {
+		clusters[i].ID = i + 1
+	}
+	return clusters
+}
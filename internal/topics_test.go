@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClusterCommentsTFIDF(t *testing.T) {
+	texts := []string{
+		"music sound great music",
+		"great music and sound",
+		"sound music love",
+		"camera video quality bad",
+		"video camera quality poor",
+		"bad camera video",
+	}
+	comments := make([]Comment, len(texts))
+	for i, text := range texts {
+		comments[i] = Comment{Text: text}
+	}
+	labels := []string{"긍정", "긍정", "긍정", "부정", "부정", "중립"}
+	res := ClusterComments(comments, labels, 2, false, map[string]bool{"and": true})
+	if res.Method != TopicByTFIDF {
+		t.Fatalf("벡터화 방식 %q, 기대값 %q", res.Method, TopicByTFIDF)
+	}
+	if len(res.Clusters) != 2 {
+		t.Fatalf("군집 %d개, 기대값 2개", len(res.Clusters))
+	}
+	// 음악 댓글(긍정 3)과 카메라 댓글(부정 2, 중립 1)이 각각 한 군집으로 묶여야 함
+	compositions := map[[3]int]bool{}
+	for _, cl := range res.Clusters {
+		compositions[[3]int{cl.PosCount, cl.NegCount, cl.NeuCount}] = true
+	}
+	if want := map[[3]int]bool{{3, 0, 0}: true, {0, 2, 1}: true}; !reflect.DeepEqual(compositions, want) {
+		t.Errorf("군집별 [긍정 부정 중립] 구성 = %v, 기대값 %v", compositions, want)
+	}
+	for _, cl := range res.Clusters {
+		if cl.Size != 3 {
+			t.Errorf("%d번 군집 크기 %d, 기대값 3", cl.ID, cl.Size)
+		}
+		if len(cl.Keywords) == 0 || len(cl.Examples) == 0 {
+			t.Errorf("%d번 군집에 키워드나 예시 댓글이 없음: %+v", cl.ID, cl)
+		}
+	}
+}
+
+func TestClusterCommentsTooFew(t *testing.T) {
+	res := ClusterComments([]Comment{{Text: "hello"}}, []string{"중립"}, 0, false, nil)
+	if len(res.Clusters) != 0 {
+		t.Errorf("댓글 1개로 군집 %d개 생성, 기대값 0개", len(res.Clusters))
+	}
+}
+
+func TestFetchEmbeddingsErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{"JSON 오류 응답", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"message":"rate limit"}}`))
+		}, "임베딩 API 오류(429): rate limit"},
+		{"JSON이 아닌 오류 응답", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+		}, "임베딩 API 오류: 502 Bad Gateway"},
+		{"응답이 멈추면 제한 시간 초과", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}, "Timeout"},
+	}
+	orig := embeddingsClient
+	defer func() { embeddingsClient = orig }()
+	embeddingsClient = &http.Client{Timeout: 50 * time.Millisecond}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(tc.handler)
+			defer srv.Close()
+			t.Setenv("OPENAI_EMBEDDINGS_URL", srv.URL)
+			_, err := FetchEmbeddings([]string{"a"})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("에러 = %v, 기대값 %q 포함", err, tc.wantErr)
+			}
+		})
+	}
+}
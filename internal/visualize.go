@@ -1,25 +1,20 @@
 package internal
 
 import (
+	"io"
 	"os"
-	"regexp"
 	"sort"
-	"strings"
 
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/opts"
 )
 
-// CountWords: 댓글 배열에서 단어 빈도 맵 생성
+// CountWords: 댓글 배열에서 단어 빈도 맵 생성 (댓글마다 언어를 감지해 해당 언어 토크나이저 사용,
+// 한글은 조사/어미를 뗀 어간 기준)
 func CountWords(comments []string) map[string]int {
 	freq := make(map[string]int)
-	wordRe := regexp.MustCompile(`\p{L}+[\p{L}\p{N}']*`)
 	for _, text := range comments {
-		words := wordRe.FindAllString(strings.ToLower(text), -1)
-		for _, w := range words {
-			if len(w) < 2 { // 한 글자 단어 제외
-				continue
-			}
+		for _, w := range TokenizeComment(text) {
 			freq[w]++
 		}
 	}
@@ -44,8 +39,38 @@ func TopNWords(freq map[string]int, n int) []string {
 	return res
 }
 
-// GeneratePieChart: 감성분석 결과 비율 파이차트 SVG 생성
+// GeneratePieChart: 감성분석 결과 비율 파이차트 HTML 생성 (echarts 스크립트를 CDN에서 불러옴.
+// 스크립트 없이 보여야 하는 리포트/이메일에는 SentimentPieSVG 사용)
 func GeneratePieChart(labels []string, filePath string) error {
+	return generateLabelPie("감성분석", labels, filePath)
+}
+
+// GenerateEmotionChart: 세부 감정 분포 파이차트 생성
+func GenerateEmotionChart(emotions []string, filePath string) error {
+	return generateLabelPie("감정분석", emotions, filePath)
+}
+
+// chartRenderer: go-echarts 차트 공통 렌더링 (파일 저장과 HTTP 응답에 같이 사용)
+type chartRenderer interface {
+	Render(w io.Writer) error
+}
+
+// saveChart: 차트를 HTML 파일로 저장
+func saveChart(c chartRenderer, filePath string) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Render(f)
+}
+
+// generateLabelPie: 라벨 배열의 개수를 세어 파이차트 HTML로 저장
+func generateLabelPie(seriesName string, labels []string, filePath string) error {
+	return saveChart(labelPie(seriesName, labels), filePath)
+}
+
+func labelPie(seriesName string, labels []string) *charts.Pie {
 	count := map[string]int{}
 	for _, l := range labels {
 		count[l]++
@@ -55,28 +80,21 @@ func GeneratePieChart(labels []string, filePath string) error {
 		items = append(items, opts.PieData{Name: k, Value: v})
 	}
 	pie := charts.NewPie()
-	pie.AddSeries("감성분석", items)
+	pie.AddSeries(seriesName, items)
 	pie.SetGlobalOptions()
-	f, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	return pie.Render(f)
+	return pie
 }
 
 func GenerateWordCloud(words map[string]int, filePath string) error {
-	// 워드클라우드 SVG 생성 예시 (실제 구현 필요)
+	return saveChart(wordCloud(words), filePath)
+}
+
+func wordCloud(words map[string]int) *charts.WordCloud {
 	wc := charts.NewWordCloud()
 	items := make([]opts.WordCloudData, 0, len(words))
 	for k, v := range words {
 		items = append(items, opts.WordCloudData{Name: k, Value: v})
 	}
 	wc.AddSeries("wordcloud", items)
-	f, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	return wc.Render(f)
+	return wc
 }
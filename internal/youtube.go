@@ -6,14 +6,25 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
-	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type Comment struct {
-	Author string
-	Text   string
+	Author      string
+	Text        string
+	Timestamps  []string // 댓글에 적힌 영상 타임스탬프
+	Links       []string // 댓글 본문에서 분리한 링크
+	Language    string   // 감지한 언어 코드 (ko, en, ja 등)
+	PublishedAt time.Time
+	LikeCount   int
+	ReplyCount  int
+}
+
+// Engagement: 좋아요 수 + 답글 수
+func (c Comment) Engagement() int {
+	return c.LikeCount + c.ReplyCount
 }
 
 func FetchComments(videoID string) ([]Comment, error) {
@@ -37,8 +48,12 @@ func FetchComments(videoID string) ([]Comment, error) {
 						Snippet struct {
 							AuthorDisplayName string `json:"authorDisplayName"`
 							TextDisplay       string `json:"textDisplay"`
+							TextOriginal      string `json:"textOriginal"`
+							PublishedAt       string `json:"publishedAt"`
+							LikeCount         int    `json:"likeCount"`
 						} `json:"snippet"`
 					} `json:"topLevelComment"`
+					TotalReplyCount int `json:"totalReplyCount"`
 				} `json:"snippet"`
 			} `json:"items"`
 			NextPageToken string `json:"nextPageToken"`
@@ -48,7 +63,23 @@ func FetchComments(videoID string) ([]Comment, error) {
 		}
 		for _, item := range result.Items {
 			c := item.Snippet.TopLevelComment.Snippet
-			comments = append(comments, Comment{Author: c.AuthorDisplayName, Text: c.TextDisplay})
+			// textOriginal(원문)을 우선 사용, 없으면 HTML이 섞인 textDisplay 사용
+			raw := c.TextOriginal
+			if raw == "" {
+				raw = c.TextDisplay
+			}
+			norm := NormalizeCommentText(raw)
+			publishedAt, _ := time.Parse(time.RFC3339, c.PublishedAt)
+			comments = append(comments, Comment{
+				Author:      c.AuthorDisplayName,
+				Text:        norm.Text,
+				Timestamps:  norm.Timestamps,
+				Links:       norm.Links,
+				Language:    DetectLanguage(norm.Text),
+				PublishedAt: publishedAt,
+				LikeCount:   c.LikeCount,
+				ReplyCount:  item.Snippet.TotalReplyCount,
+			})
 			if len(comments) >= 300 {
 				break
 			}
@@ -61,19 +92,23 @@ func FetchComments(videoID string) ([]Comment, error) {
 	return comments, nil
 }
 
-// ParseVideoID: 입력값에서 유튜브 영상 ID 추출 (URL 또는 ID)
+// ParseVideoID: 입력값에서 유튜브 영상 ID 추출 (URL 또는 ID, 영상이 아니면 빈 문자열)
 func ParseVideoID(input string) string {
-	// 1. ID만 입력된 경우
-	if len(input) == 11 && !strings.Contains(input, "/") {
-		return input
+	ref, err := ParseYouTubeRef(input)
+	if err != nil || !ref.IsVideo() {
+		return ""
 	}
-	// 2. URL에서 추출
-	re := regexp.MustCompile(`(?i)(?:v=|youtu.be/|embed/|shorts/)([\w-]{11})`)
-	matches := re.FindStringSubmatch(input)
-	if len(matches) > 1 {
-		return matches[1]
+	return ref.ID
+}
+
+// ParsePlaylistID: 재생목록 URL/ID 또는 영상 URL의 list= 값에서 재생목록 ID 추출.
+// 자동 생성 믹스(RD로 시작)는 API로 항목을 조회할 수 없어 빈 문자열 반환
+func ParsePlaylistID(input string) string {
+	ref, err := ParseYouTubeRef(input)
+	if err != nil || strings.HasPrefix(ref.PlaylistID, "RD") {
+		return ""
 	}
-	return ""
+	return ref.PlaylistID
 }
 
 // FetchRandomPopularVideoID: 인기 영상 중 랜덤으로 하나의 ID 반환
@@ -103,15 +138,18 @@ func FetchRandomPopularVideoID() (string, error) {
 
 // VideoMeta: 썸네일, 채널명, 제목 등 메타데이터 구조체
 type VideoMeta struct {
-	Title     string
-	Channel   string
-	Thumbnail string
+	Title        string
+	Channel      string
+	ChannelID    string
+	Thumbnail    string
+	PublishedAt  time.Time
+	CommentCount int // 영상의 전체 댓글 수
 }
 
 // FetchVideoMeta: 영상 ID로 메타데이터(제목, 채널명, 썸네일) 조회
 func FetchVideoMeta(videoID string) (VideoMeta, error) {
 	apiKey := os.Getenv("YOUTUBE_API_KEY")
-	url := fmt.Sprintf("https://www.googleapis.com/youtube/v3/videos?part=snippet&id=%s&key=%s", videoID, apiKey)
+	url := fmt.Sprintf("https://www.googleapis.com/youtube/v3/videos?part=snippet,statistics&id=%s&key=%s", videoID, apiKey)
 	resp, err := http.Get(url)
 	if err != nil {
 		return VideoMeta{}, err
@@ -122,6 +160,8 @@ func FetchVideoMeta(videoID string) (VideoMeta, error) {
 			Snippet struct {
 				Title        string `json:"title"`
 				ChannelTitle string `json:"channelTitle"`
+				ChannelID    string `json:"channelId"`
+				PublishedAt  string `json:"publishedAt"`
 				Thumbnails   struct {
 					Default struct {
 						URL string `json:"url"`
@@ -134,6 +174,9 @@ func FetchVideoMeta(videoID string) (VideoMeta, error) {
 					} `json:"high"`
 				} `json:"thumbnails"`
 			} `json:"snippet"`
+			Statistics struct {
+				CommentCount string `json:"commentCount"`
+			} `json:"statistics"`
 		} `json:"items"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -143,6 +186,7 @@ func FetchVideoMeta(videoID string) (VideoMeta, error) {
 		return VideoMeta{}, fmt.Errorf("영상 정보를 찾을 수 없음")
 	}
 	snippet := result.Items[0].Snippet
+	publishedAt, _ := time.Parse(time.RFC3339, snippet.PublishedAt)
 	thumb := snippet.Thumbnails.High.URL
 	if thumb == "" {
 		thumb = snippet.Thumbnails.Medium.URL
@@ -150,9 +194,13 @@ func FetchVideoMeta(videoID string) (VideoMeta, error) {
 	if thumb == "" {
 		thumb = snippet.Thumbnails.Default.URL
 	}
+	commentCount, _ := strconv.Atoi(result.Items[0].Statistics.CommentCount)
 	return VideoMeta{
-		Title:     snippet.Title,
-		Channel:   snippet.ChannelTitle,
-		Thumbnail: thumb,
+		Title:        snippet.Title,
+		Channel:      snippet.ChannelTitle,
+		ChannelID:    snippet.ChannelID,
+		PublishedAt:  publishedAt,
+		Thumbnail:    thumb,
+		CommentCount: commentCount,
 	}, nil
 }
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// YouTubeRefKind: 입력값이 가리키는 대상 종류
+type YouTubeRefKind string
+
+const (
+	RefVideo     YouTubeRefKind = "video"
+	RefShort     YouTubeRefKind = "short"
+	RefLive      YouTubeRefKind = "live"
+	RefPlaylist  YouTubeRefKind = "playlist"
+	RefChannelID YouTubeRefKind = "channel"
+	RefHandle    YouTubeRefKind = "handle"
+	RefCustomURL YouTubeRefKind = "custom"   // /c/이름 또는 youtube.com/이름
+	RefUsername  YouTubeRefKind = "username" // 옛 /user/이름
+)
+
+// YouTubeRef: 유튜브 URL/ID 해석 결과.
+// ID는 종류별 식별자 (영상 ID, 재생목록 ID, UC로 시작하는 채널 ID, @핸들, 사용자 지정 이름)
+type YouTubeRef struct {
+	Kind         YouTubeRefKind
+	ID           string
+	PlaylistID   string // 영상 URL에 함께 붙은 list= (재생목록이면 ID와 같음)
+	StartSeconds int    // t=, start= 로 지정된 시작 위치(초)
+}
+
+// IsVideo: 영상(일반/쇼츠/라이브)을 가리키는지 여부
+func (ref YouTubeRef) IsVideo() bool {
+	return ref.Kind == RefVideo || ref.Kind == RefShort || ref.Kind == RefLive
+}
+
+var (
+	videoIDRe    = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	playlistIDRe = regexp.MustCompile(`^(PL|UU|LL|FL|OL|RD|UL|PU)[A-Za-z0-9_-]{10,}$`)
+	channelIDRe  = regexp.MustCompile(`^UC[A-Za-z0-9_-]{22}$`)
+	handleRe     = regexp.MustCompile(`^@[\p{L}\p{N}_.-]{3,30}$`)
+	customNameRe = regexp.MustCompile(`^[\p{L}\p{N}_.-]+$`)
+	startTimeRe  = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+)
+
+// youtube.com 아래에서 채널 이름이 아닌 최상위 경로
+var reservedYouTubePaths = map[string]bool{
+	"feed": true, "results": true, "watch": true, "playlist": true, "channel": true, "c": true, "user": true,
+	"shorts": true, "live": true, "embed": true, "v": true, "e": true, "redirect": true, "account": true,
+	"premium": true, "gaming": true, "hashtag": true, "signin": true, "logout": true, "about": true,
+}
+
+// ParseYouTubeRef: 영상/쇼츠/라이브/재생목록/채널 URL, @핸들, 맨 ID를 해석.
+// m., music., www. 하위 도메인과 youtu.be, youtube-nocookie.com을 지원하고 추적용 파라미터(si, feature 등)는 무시
+func ParseYouTubeRef(input string) (YouTubeRef, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return YouTubeRef{}, fmt.Errorf("빈 입력값")
+	}
+	switch {
+	case videoIDRe.MatchString(input):
+		return YouTubeRef{Kind: RefVideo, ID: input}, nil
+	case channelIDRe.MatchString(input):
+		return YouTubeRef{Kind: RefChannelID, ID: input}, nil
+	case playlistIDRe.MatchString(input):
+		return YouTubeRef{Kind: RefPlaylist, ID: input, PlaylistID: input}, nil
+	case handleRe.MatchString(input):
+		return YouTubeRef{Kind: RefHandle, ID: input}, nil
+	}
+
+	raw := input
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return YouTubeRef{}, fmt.Errorf("URL을 해석할 수 없음: %w", err)
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, prefix := range []string{"www.", "m.", "music."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	q := u.Query()
+	ref := YouTubeRef{StartSeconds: parseStartTime(q, u.Fragment)}
+	if list := q.Get("list"); playlistIDRe.MatchString(list) {
+		ref.PlaylistID = list
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch host {
+	case "youtu.be":
+		return videoRef(ref, RefVideo, parts[0])
+	case "youtube.com", "youtube-nocookie.com":
+	default:
+		return YouTubeRef{}, fmt.Errorf("유튜브 주소가 아닙니다: %s", host)
+	}
+
+	switch first := parts[0]; {
+	case first == "watch":
+		if v := q.Get("v"); v != "" {
+			return videoRef(ref, RefVideo, v)
+		}
+		if ref.PlaylistID != "" {
+			return YouTubeRef{Kind: RefPlaylist, ID: ref.PlaylistID, PlaylistID: ref.PlaylistID}, nil
+		}
+	case first == "playlist":
+		if ref.PlaylistID != "" {
+			return YouTubeRef{Kind: RefPlaylist, ID: ref.PlaylistID, PlaylistID: ref.PlaylistID}, nil
+		}
+		return YouTubeRef{}, fmt.Errorf("유효한 재생목록 ID가 없습니다")
+	case len(parts) < 2:
+		// youtube.com/@핸들, youtube.com/이름 (옛 사용자 지정 URL)
+		if strings.HasPrefix(first, "@") && handleRe.MatchString(first) {
+			return YouTubeRef{Kind: RefHandle, ID: first}, nil
+		}
+		if first != "" && !reservedYouTubePaths[first] && customNameRe.MatchString(first) {
+			return YouTubeRef{Kind: RefCustomURL, ID: first}, nil
+		}
+	case first == "shorts":
+		return videoRef(ref, RefShort, parts[1])
+	case first == "live":
+		return videoRef(ref, RefLive, parts[1])
+	case first == "embed" || first == "v" || first == "e":
+		return videoRef(ref, RefVideo, parts[1])
+	case first == "channel" && channelIDRe.MatchString(parts[1]):
+		return YouTubeRef{Kind: RefChannelID, ID: parts[1]}, nil
+	case first == "c" && customNameRe.MatchString(parts[1]):
+		return YouTubeRef{Kind: RefCustomURL, ID: parts[1]}, nil
+	case first == "user" && customNameRe.MatchString(parts[1]):
+		return YouTubeRef{Kind: RefUsername, ID: parts[1]}, nil
+	case strings.HasPrefix(first, "@") && handleRe.MatchString(first):
+		// youtube.com/@핸들/videos 같은 채널 하위 탭
+		return YouTubeRef{Kind: RefHandle, ID: first}, nil
+	}
+	return YouTubeRef{}, fmt.Errorf("지원하지 않는 유튜브 주소입니다: %s", input)
+}
+
+// videoRef: 영상 ID 형식을 검증해 영상 종류 결과 생성
+func videoRef(ref YouTubeRef, kind YouTubeRefKind, id string) (YouTubeRef, error) {
+	if !videoIDRe.MatchString(id) {
+		return YouTubeRef{}, fmt.Errorf("유효하지 않은 영상 ID: %q", id)
+	}
+	ref.Kind, ref.ID = kind, id
+	return ref, nil
+}
+
+// parseStartTime: t=90, t=1m30s, t=1h2m3s, start=90, #t=90 형식의 시작 위치를 초로 변환
+func parseStartTime(q url.Values, fragment string) int {
+	value := q.Get("t")
+	if value == "" {
+		value = q.Get("start")
+	}
+	if value == "" && strings.HasPrefix(fragment, "t=") {
+		value = strings.TrimPrefix(fragment, "t=")
+	}
+	m := startTimeRe.FindStringSubmatch(value)
+	if value == "" || m == nil {
+		return 0
+	}
+	seconds := 0
+	for i, mult := range []int{3600, 60, 1} {
+		if n, err := strconv.Atoi(m[i+1]); err == nil {
+			seconds += n * mult
+		}
+	}
+	return seconds
+}
@@ -0,0 +1,85 @@
+package internal
+
+import "testing"
+
+func TestParseYouTubeRef(t *testing.T) {
+	const (
+		vid     = "dQw4w9WgXcQ"
+		list    = "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf"
+		channel = "UC38IQsAvIsxxjztdMZQtwHA"
+	)
+	cases := []struct {
+		name  string
+		input string
+		want  YouTubeRef
+	}{
+		{"영상 ID만", vid, YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"watch 링크", "https://www.youtube.com/watch?v=" + vid, YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"스킴 없는 watch 링크", "youtube.com/watch?v=" + vid, YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"모바일", "https://m.youtube.com/watch?v=" + vid + "&feature=share", YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"유튜브 뮤직", "https://music.youtube.com/watch?v=" + vid + "&si=abc", YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"추적 파라미터가 붙은 짧은 링크", "https://youtu.be/" + vid + "?si=XyZ123", YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"짧은 링크 시작 시각", "https://youtu.be/" + vid + "?t=90", YouTubeRef{Kind: RefVideo, ID: vid, StartSeconds: 90}},
+		{"watch 링크 시분초 시작 시각", "https://www.youtube.com/watch?v=" + vid + "&t=1h2m3s", YouTubeRef{Kind: RefVideo, ID: vid, StartSeconds: 3723}},
+		{"프래그먼트 시작 시각", "https://www.youtube.com/watch?v=" + vid + "#t=1m30s", YouTubeRef{Kind: RefVideo, ID: vid, StartSeconds: 90}},
+		{"임베드 start", "https://www.youtube.com/embed/" + vid + "?start=42", YouTubeRef{Kind: RefVideo, ID: vid, StartSeconds: 42}},
+		{"nocookie 임베드", "https://www.youtube-nocookie.com/embed/" + vid, YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"/v/ 경로", "https://www.youtube.com/v/" + vid, YouTubeRef{Kind: RefVideo, ID: vid}},
+		{"쇼츠", "https://www.youtube.com/shorts/" + vid + "?feature=share", YouTubeRef{Kind: RefShort, ID: vid}},
+		{"라이브", "https://www.youtube.com/live/" + vid + "?si=abc", YouTubeRef{Kind: RefLive, ID: vid}},
+		{"재생목록 안의 영상", "https://www.youtube.com/watch?v=" + vid + "&list=" + list + "&index=3", YouTubeRef{Kind: RefVideo, ID: vid, PlaylistID: list}},
+		{"재생목록", "https://www.youtube.com/playlist?list=" + list, YouTubeRef{Kind: RefPlaylist, ID: list, PlaylistID: list}},
+		{"재생목록 ID만", list, YouTubeRef{Kind: RefPlaylist, ID: list, PlaylistID: list}},
+		{"채널 ID URL", "https://www.youtube.com/channel/" + channel + "/videos", YouTubeRef{Kind: RefChannelID, ID: channel}},
+		{"채널 ID만", channel, YouTubeRef{Kind: RefChannelID, ID: channel}},
+		{"핸들 URL", "https://www.youtube.com/@침착맨", YouTubeRef{Kind: RefHandle, ID: "@침착맨"}},
+		{"핸들 채널 탭", "https://www.youtube.com/@GoogleDevelopers/videos", YouTubeRef{Kind: RefHandle, ID: "@GoogleDevelopers"}},
+		{"핸들만", "@GoogleDevelopers", YouTubeRef{Kind: RefHandle, ID: "@GoogleDevelopers"}},
+		{"맞춤 URL", "https://www.youtube.com/c/GoogleDevelopers", YouTubeRef{Kind: RefCustomURL, ID: "GoogleDevelopers"}},
+		{"예전 맞춤 URL", "https://www.youtube.com/GoogleDevelopers", YouTubeRef{Kind: RefCustomURL, ID: "GoogleDevelopers"}},
+		{"사용자 URL", "https://www.youtube.com/user/GoogleDevelopers", YouTubeRef{Kind: RefUsername, ID: "GoogleDevelopers"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseYouTubeRef(tc.input)
+			if err != nil {
+				t.Fatalf("ParseYouTubeRef(%q) 실패: %v", tc.input, err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseYouTubeRef(%q) = %+v, 기대값 %+v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseYouTubeRefInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"hello world",
+		"abc/def/ghi",            // 11글자지만 ID 문자가 아님
+		"https://youtu.be/short", // 영상 ID 길이 오류
+		"https://www.youtu.be.evil.com/watch?v=dQw4w9WgXcQ",
+		"https://youtuXbe/dQw4w9WgXcQ", // 점 자리에 다른 문자
+		"https://vimeo.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=dQw4w9WgXc!",
+		"https://www.youtube.com/shorts/",
+		"https://www.youtube.com/feed",
+	}
+	for _, in := range inputs {
+		if ref, err := ParseYouTubeRef(in); err == nil {
+			t.Errorf("ParseYouTubeRef(%q) = %+v, 에러가 나야 함", in, ref)
+		}
+	}
+}
+
+func TestParseVideoAndPlaylistID(t *testing.T) {
+	if got := ParseVideoID("https://www.youtube.com/@GoogleDevelopers"); got != "" {
+		t.Errorf("채널 URL의 영상 ID = %q, 기대값 없음", got)
+	}
+	if got := ParseVideoID("https://youtu.be/dQw4w9WgXcQ?t=10"); got != "dQw4w9WgXcQ" {
+		t.Errorf("youtu.be 링크의 영상 ID = %q", got)
+	}
+	if got := ParsePlaylistID("https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=RDdQw4w9WgXcQ"); got != "" {
+		t.Errorf("믹스 재생목록 ID = %q, 기대값 없음", got)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -29,12 +30,29 @@ func main() {
 	}
 	// Firebase Admin SDK 초기화 불필요 (REST API만 사용)
 
+	// 진행 중인 모임 영상의 감성 변화 주기적 재분석 (DRIFT_INTERVAL=0이면 끔)
+	internal.StartDriftScheduler(context.Background())
+
 	http.HandleFunc("/signup", internal.SignupHandler)
 	http.HandleFunc("/login", internal.LoginHandler)
 	http.HandleFunc("/logout", internal.LogoutHandler)
 
 	http.HandleFunc("/", internal.IndexHandler)
 	http.HandleFunc("/analyze", internal.AuthRequired(internal.AnalyzeHandler))
+	http.HandleFunc("/api/analyze", internal.AuthRequired(internal.AnalyzeAPIHandler))
+	http.HandleFunc("/analysis", internal.AuthRequired(internal.AnalysisPermalinkHandler))
+	http.HandleFunc("/analysis/chart", internal.AuthRequired(internal.AnalysisChartHandler))
+	http.HandleFunc("/analysis/export", internal.AuthRequired(internal.ExportHandler))
+	http.HandleFunc("/api/analysis/export", internal.AuthRequired(internal.ExportHandler))
+	http.HandleFunc("/report", internal.ReportHandler)
+	http.HandleFunc("/api/analysis", internal.AuthRequired(internal.AnalysisRecordAPIHandler))
+	http.HandleFunc("/history", internal.AuthRequired(internal.HistoryHandler))
+	http.HandleFunc("/api/history", internal.AuthRequired(internal.HistoryAPIHandler))
+	http.HandleFunc("/compare", internal.AuthRequired(internal.CompareHandler))
+	http.HandleFunc("/api/compare", internal.AuthRequired(internal.CompareAPIHandler))
+	http.HandleFunc("/channel", internal.AuthRequired(internal.ChannelHandler))
+	http.HandleFunc("/api/channel", internal.AuthRequired(internal.ChannelAPIHandler))
+	http.HandleFunc("/api/stopwords", internal.AuthRequired(internal.StopwordsAPIHandler))
 	http.HandleFunc("/create", internal.AuthRequired(internal.CreateMeetingHandler))
 	http.HandleFunc("/my-meetings", internal.AuthRequired(internal.MyMeetingsHandler))
 	http.HandleFunc("/meeting", internal.AuthRequired(internal.MeetingDetailHandler))